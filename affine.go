@@ -0,0 +1,159 @@
+// Ralf Poeppel, 2026
+//
+// This file implements affine sub vector spaces, the cosets v + U of a sub
+// vector space U. Each coset has a unique representative, the offset
+// reduced by the basis of U, its leading coordinates of the basis are zero.
+// The solutions of a system of xor equations are an affine sub vector space.
+
+package gf2vs
+
+import (
+	"fmt"
+	"iter"
+	"math/bits"
+	"slices"
+)
+
+// GF2AffineSpace represents the affine sub vector space offset + sub.
+type GF2AffineSpace struct {
+	offset *GF2Vector // canonical representative of the coset
+	sub    *GF2Span   // the direction, a sub vector space
+}
+
+// NewGF2AffineSpace create the affine sub vector space v + sub.
+// Panic if v and sub are of vector spaces of different dimension.
+func NewGF2AffineSpace(v *GF2Vector, sub *GF2Span) *GF2AffineSpace {
+	if v.sp.dim != sub.sp.dim {
+		panic(mismatch("NewGF2AffineSpace", v.sp.dim, sub.sp.dim).Error())
+	}
+	return &GF2AffineSpace{sub.reduce(new(GF2Vector), v), sub}
+}
+
+// Coset return the coset v + span.
+// Panic if v and span are of vector spaces of different dimension.
+func (span *GF2Span) Coset(v *GF2Vector) *GF2AffineSpace {
+	return NewGF2AffineSpace(v, span)
+}
+
+// Cosets return an iterator over all cosets of span in its vector space.
+// There are 2**(dim - span.Dim()) cosets. The representatives are all
+// vectors, which are zero at the leading coordinates of the basis of span.
+// They are enumerated in Gray code order, each one differs in one coordinate
+// from its predecessor. The first coset is span itself.
+func (span *GF2Span) Cosets() iter.Seq[*GF2AffineSpace] {
+	return func(yield func(*GF2AffineSpace) bool) {
+		pivot := span.sp.GF2Zeros()
+		for _, b := range span.basis {
+			pivot.SetBit(leading(b), 1)
+		}
+		var free []uint
+		for f := range pivot.Unset() {
+			free = append(free, f)
+		}
+		rep := span.sp.GF2Zeros()
+		for i := uint64(1); ; i++ {
+			if !yield(&GF2AffineSpace{rep.Copy(), span}) {
+				return
+			}
+			k := bits.TrailingZeros64(i)
+			if k >= len(free) {
+				return
+			}
+			rep.FlipBit(free[k])
+		}
+	}
+}
+
+func (a *GF2AffineSpace) String() string {
+	return fmt.Sprintf("GF(2)aff{%v + %v}", a.offset, a.sub.basis)
+}
+
+// Offset return a copy of the canonical representative of the coset a.
+// Two affine sub vector spaces are equal if their offsets and directions are equal.
+func (a *GF2AffineSpace) Offset() *GF2Vector {
+	return a.offset.Copy()
+}
+
+// Direction return the sub vector space of a, the differences of its vectors.
+func (a *GF2AffineSpace) Direction() *GF2Span {
+	return a.sub
+}
+
+// Dim return the dimension of a, the dimension of its direction.
+func (a *GF2AffineSpace) Dim() uint {
+	return a.sub.Dim()
+}
+
+// Contains return true if v is an element of a.
+// Panic if v is of a vector space of other dimension.
+func (a *GF2AffineSpace) Contains(v *GF2Vector) bool {
+	if a.sub.sp.dim != v.sp.dim {
+		panic(mismatch("Contains", a.sub.sp.dim, v.sp.dim).Error())
+	}
+	return a.sub.Contains(new(GF2Vector).Xor(v, a.offset))
+}
+
+// Equal return true if a and b are the same affine sub vector space.
+func (a *GF2AffineSpace) Equal(b *GF2AffineSpace) bool {
+	return a.sub.Equal(b.sub) && slices.Equal(wordsOf(a.offset), wordsOf(b.offset))
+}
+
+// Intersect return the intersection of a and b, and true.
+// If the intersection is empty nil and false are returned.
+// Panic if a and b are of vector spaces of different dimension.
+func (a *GF2AffineSpace) Intersect(b *GF2AffineSpace) (*GF2AffineSpace, bool) {
+	u, w := a.sub, b.sub
+	if u.sp.dim != w.sp.dim {
+		panic(mismatch("Intersect", u.sp.dim, w.sp.dim).Error())
+	}
+	// a.offset + x = b.offset + y for x of u and y of w,
+	// so d = a.offset + b.offset is x + y, a vector of the sum of u and w
+	d := new(GF2Vector).Xor(a.offset, b.offset)
+	n := len(u.basis)
+	e := newEliminator(n + len(w.basis) + 1)
+	for i, v := range u.basis {
+		e.add(i, v)
+	}
+	for i, v := range w.basis {
+		e.add(n+i, v)
+	}
+	c := e.add(n+len(w.basis), d)
+	if c == nil {
+		return nil, false
+	}
+	// x is the part of u of the combination
+	x := d.Zeros()
+	for _, i := range indices(c) {
+		if i < n {
+			x.Xor(x, u.basis[i])
+		}
+	}
+	return NewGF2AffineSpace(x.Xor(x, a.offset), u.Intersect(w)), true
+}
+
+// Preimage return the solutions of f(x) = y, the affine sub vector space
+// x + kernel of f for any solution x, and true.
+// If there is no solution nil and false are returned.
+// Panic if y is not of the codomain of f.
+func (f *GF2LinearMap) Preimage(y *GF2Vector) (*GF2AffineSpace, bool) {
+	if y.sp.dim != f.to.dim {
+		panic(mismatch("Preimage", f.to.dim, y.sp.dim).Error())
+	}
+	// find a combination of the images of the base vectors adding up to y
+	n := int(f.from.dim)
+	e := newEliminator(n + 1)
+	for i := range n {
+		e.add(i, f.Apply(f.from.GF2BaseVector(uint(i)+1)))
+	}
+	c := e.add(n, y)
+	if c == nil {
+		return nil, false
+	}
+	x := f.from.GF2Zeros()
+	for _, i := range indices(c) {
+		if i < n {
+			x.SetBit(uint(i)+1, 1)
+		}
+	}
+	return NewGF2AffineSpace(x, f.Kernel()), true
+}
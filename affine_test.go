@@ -0,0 +1,176 @@
+// Ralf Poeppel, 2026
+
+package gf2vs
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"testing"
+)
+
+func TestSpanSumIntersect(t *testing.T) {
+	cases := []struct {
+		dim       uint
+		u, w      []uint
+		sum       string
+		intersect string
+	}{
+		{3, []uint{0}, []uint{0b111}, "GF(2)span{3: [111]}", "GF(2)span{3: []}"},
+		{3, []uint{0b011, 0b110}, []uint{0b101}, "GF(2)span{3: [101 011]}", "GF(2)span{3: [101]}"},
+		{3, []uint{0b001, 0b010}, []uint{0b010, 0b100}, "GF(2)span{3: [100 010 001]}", "GF(2)span{3: [010]}"},
+		{4, []uint{0b1100, 0b0011}, []uint{0b1010, 0b0101}, "GF(2)span{4: [1001 0101 0011]}", "GF(2)span{4: [1111]}"},
+	}
+	for _, c := range cases {
+		sp := NewGF2VectorSpace(c.dim)
+		u := SpanOf(vectors(sp, c.u...))
+		w := SpanOf(vectors(sp, c.w...))
+		if got := u.Sum(w).String(); got != c.sum {
+			t.Errorf("%v.Sum(%v) = %v, want %v", u, w, got, c.sum)
+		}
+		if got := u.Intersect(w).String(); got != c.intersect {
+			t.Errorf("%v.Intersect(%v) = %v, want %v", u, w, got, c.intersect)
+		}
+		if u.Sum(w).Dim()+u.Intersect(w).Dim() != u.Dim()+w.Dim() {
+			t.Errorf("dim of sum and intersection of %v, %v wrong", u, w)
+		}
+	}
+}
+
+func TestGF2AffineSpace(t *testing.T) {
+	sp := NewGF2VectorSpace(4)
+	sub := SpanOf(vectors(sp, 0b1100, 0b0011))
+	a := sub.Coset(sp.NewGF2Vector(0b1101))
+	if got := a.String(); got != "GF(2)aff{0001 + [1100 0011]}" {
+		t.Errorf("Coset(1101) = %v, want GF(2)aff{0001 + [1100 0011]}", got)
+	}
+	if a.Dim() != 2 || !a.Direction().Equal(sub) {
+		t.Errorf("%v.Dim() = %v, Direction() = %v", a, a.Dim(), a.Direction())
+	}
+	for v := range uint(16) {
+		want := v == 0b0001 || v == 0b1101 || v == 0b0010 || v == 0b1110
+		if got := a.Contains(sp.NewGF2Vector(v)); got != want {
+			t.Errorf("%v.Contains(%04b) = %v, want %v", a, v, got, want)
+		}
+	}
+	// each element of a coset gives the same coset
+	for _, v := range []uint{0b0001, 0b1101, 0b0010, 0b1110} {
+		b := sub.Coset(sp.NewGF2Vector(v))
+		if !b.Equal(a) || b.Offset().String() != "0001" {
+			t.Errorf("Coset(%04b) = %v, want %v", v, b, a)
+		}
+	}
+}
+
+func TestCosets(t *testing.T) {
+	cases := []struct {
+		dim  uint
+		s    []uint
+		want string
+	}{
+		{2, []uint{0b11}, "[GF(2)aff{00 + [11]} GF(2)aff{01 + [11]}]"},
+		{3, []uint{0b111}, "[GF(2)aff{000 + [111]} GF(2)aff{001 + [111]} " +
+			"GF(2)aff{011 + [111]} GF(2)aff{010 + [111]}]"},
+		{2, []uint{0b01, 0b10}, "[GF(2)aff{00 + [10 01]}]"},
+		{2, []uint{0}, "[GF(2)aff{00 + []} GF(2)aff{01 + []} " +
+			"GF(2)aff{11 + []} GF(2)aff{10 + []}]"},
+	}
+	for _, c := range cases {
+		sp := NewGF2VectorSpace(c.dim)
+		span := SpanOf(vectors(sp, c.s...))
+		var cosets []*GF2AffineSpace
+		for a := range span.Cosets() {
+			cosets = append(cosets, a)
+		}
+		if got := fmt.Sprint(cosets); got != c.want {
+			t.Errorf("%v.Cosets() = %v, want %v", span, got, c.want)
+		}
+	}
+
+	// the cosets are a partition of the vector space
+	sp := NewGF2VectorSpace(6)
+	span := SpanOf(vectors(sp, 0b110011, 0b011110))
+	seen := make(map[uint]int)
+	n := 0
+	for a := range span.Cosets() {
+		n++
+		for v := range uint(64) {
+			if a.Contains(sp.NewGF2Vector(v)) {
+				seen[v]++
+			}
+		}
+		if got := sp.NewGF2Vector(a.Offset().Val()); !span.Coset(got).Equal(a) {
+			t.Errorf("%v is not the canonical coset of %v", a, got)
+		}
+	}
+	if n != 16 || len(seen) != 64 {
+		t.Errorf("%v.Cosets() yield %v cosets covering %v vectors, want 16, 64", span, n, len(seen))
+	}
+	for v, k := range seen {
+		if k != 1 {
+			t.Errorf("%06b is in %v cosets", v, k)
+		}
+	}
+}
+
+func TestGF2AffineSpaceIntersect(t *testing.T) {
+	r := rand.New(rand.NewPCG(9, 10))
+	for _, dim := range []uint{4, 7, 70} {
+		sp := NewGF2VectorSpace(dim)
+		for range 30 {
+			u := SpanOf([]*GF2Vector{randomVector(r, sp, 32), randomVector(r, sp, 32)})
+			w := SpanOf([]*GF2Vector{randomVector(r, sp, 32), randomVector(r, sp, 32),
+				randomVector(r, sp, 32)})
+			a := u.Coset(randomVector(r, sp, 32))
+			b := w.Coset(randomVector(r, sp, 32))
+			c, ok := a.Intersect(b)
+			if !ok {
+				// no common vector, the offsets differ by no vector of u + w
+				d := Xor(a.Offset(), b.Offset())
+				if u.Sum(w).Contains(d) {
+					t.Errorf("%v.Intersect(%v) is empty", a, b)
+				}
+				continue
+			}
+			if !a.Contains(c.Offset()) || !b.Contains(c.Offset()) {
+				t.Errorf("%v.Intersect(%v) = %v is not in both", a, b, c)
+			}
+			if !c.Direction().Equal(u.Intersect(w)) {
+				t.Errorf("%v.Intersect(%v) = %v has wrong direction", a, b, c)
+			}
+		}
+	}
+	// disjoint parallel lines
+	sp := NewGF2VectorSpace(3)
+	line := SpanOf(vectors(sp, 0b001))
+	if c, ok := line.Coset(sp.NewGF2Vector(0b010)).Intersect(line.Coset(sp.NewGF2Vector(0b100))); ok {
+		t.Errorf("parallel lines intersect in %v", c)
+	}
+}
+
+func TestPreimage(t *testing.T) {
+	// xor-sat example of TestRowReducedEcholonForm
+	// a + c + d = 1, b + c + d = 0, a + b + d = 0, a + b + c = 1
+	from := NewGF2VectorSpace(4)
+	to := NewGF2VectorSpace(4)
+	f := NewGF2LinearMap(from, to, bitMatrix(0b1011, 0b0111, 0b1101, 0b1110))
+	a, ok := f.Preimage(to.NewGF2Vector(0b1001))
+	// solution a = 0, b = 1, c = 0, d = 1
+	if !ok || a.String() != "GF(2)aff{0101 + []}" {
+		t.Errorf("%v.Preimage(1001) = %v, %v, want GF(2)aff{0101 + []}, true", f, a, ok)
+	}
+	// parity has a solution for each value
+	from = NewGF2VectorSpace(3)
+	to = NewGF2VectorSpace(1)
+	f = NewGF2LinearMap(from, to, bitMatrix(0b111))
+	a, ok = f.Preimage(to.GF2Ones())
+	if !ok || a.Dim() != 2 || OnesCount(f.Apply(a.Offset())) != 1 {
+		t.Errorf("%v.Preimage(1) = %v, %v", f, a, ok)
+	}
+	// projection has no solution outside the image
+	from = NewGF2VectorSpace(2)
+	to = NewGF2VectorSpace(3)
+	f = NewGF2LinearMap(from, to, bitMatrix(0b10, 0b01, 0b00))
+	if a, ok = f.Preimage(to.NewGF2Vector(0b001)); ok {
+		t.Errorf("%v.Preimage(001) = %v, %v, want nil, false", f, a, ok)
+	}
+}
@@ -5,7 +5,9 @@ package gf2vs
 
 import (
 	"flag"
+	"math/big"
 	"math/bits"
+	"math/rand/v2"
 	"testing"
 )
 
@@ -92,3 +94,258 @@ func BenchmarkVectorSpaceVectorIsBaseVector(b *testing.B) {
 		}
 	}
 }
+
+// Benchmarks comparing the allocating functions with the methods
+// setting the receiver, run with -benchmem to see the allocations.
+// The results are stored in sinks to keep the compiler from removing them.
+
+var sinkVector *GF2Vector
+var sinkRank int
+var sinkDistance int
+
+func BenchmarkVectorXor(b *testing.B) {
+	b.ReportAllocs()
+	for b.Loop() {
+		for j := 1; j < N; j <<= 1 {
+			vs := V[j]
+			for l := 1; l < M; l++ {
+				sinkVector = Xor(vs[l-1], vs[l])
+			}
+		}
+	}
+}
+
+func BenchmarkVectorXorInPlace(b *testing.B) {
+	b.ReportAllocs()
+	var z GF2Vector
+	for b.Loop() {
+		for j := 1; j < N; j <<= 1 {
+			vs := V[j]
+			for l := 1; l < M; l++ {
+				z.Xor(vs[l-1], vs[l])
+			}
+		}
+	}
+}
+
+func BenchmarkVectorNot(b *testing.B) {
+	b.ReportAllocs()
+	for b.Loop() {
+		for j := 1; j < N; j <<= 1 {
+			for _, v := range V[j] {
+				sinkVector = Not(v)
+			}
+		}
+	}
+}
+
+func BenchmarkVectorNotInPlace(b *testing.B) {
+	b.ReportAllocs()
+	var z GF2Vector
+	for b.Loop() {
+		for j := 1; j < N; j <<= 1 {
+			for _, v := range V[j] {
+				z.Not(v)
+			}
+		}
+	}
+}
+
+// wideVectors return M vectors of a vector space of dimension 10000.
+func wideVectors() []*GF2Vector {
+	sp := NewGF2VectorSpace(10000)
+	r := rand.New(rand.NewPCG(1, 2))
+	vs := make([]*GF2Vector, M)
+	for l := range vs {
+		w := make([]uint, sp.words())
+		for i := range w {
+			w[i] = uint(r.Uint64())
+		}
+		w[len(w)-1] &= sp.ones
+		vs[l] = sp.NewGF2VectorWords(w)
+	}
+	return vs
+}
+
+func BenchmarkWideVectorXor(b *testing.B) {
+	b.ReportAllocs()
+	vs := wideVectors()
+	for b.Loop() {
+		for l := 1; l < M; l++ {
+			sinkVector = Xor(vs[l-1], vs[l])
+		}
+	}
+}
+
+func BenchmarkWideVectorXorInPlace(b *testing.B) {
+	b.ReportAllocs()
+	vs := wideVectors()
+	var z GF2Vector
+	for b.Loop() {
+		for l := 1; l < M; l++ {
+			z.Xor(vs[l-1], vs[l])
+		}
+	}
+}
+
+// wideBitMatrix return a random BitMatrix of M rows of 10000 bits.
+func wideBitMatrix() *SizedBitMatrix {
+	return randomMatrix(rand.New(rand.NewPCG(1, 2)), M, 10000)
+}
+
+func BenchmarkBitMatrixRowXor(b *testing.B) {
+	b.ReportAllocs()
+	rows := wideBitMatrix().RowVectors()
+	for b.Loop() {
+		for l := 1; l < M; l++ {
+			sinkVector = Xor(rows[l-1], rows[l])
+		}
+	}
+}
+
+func BenchmarkBitMatrixRowXorInPlace(b *testing.B) {
+	b.ReportAllocs()
+	rows := wideBitMatrix().RowVectors()
+	for b.Loop() {
+		for l := 1; l < M; l++ {
+			rows[l].Xor(rows[l], rows[l-1])
+		}
+	}
+}
+
+// BenchmarkBitMatrixRowReduce reduce a BitMatrix, its rows are xored in
+// place, the matrix is set again into the same rows before each reduction.
+func BenchmarkBitMatrixRowReduce(b *testing.B) {
+	b.ReportAllocs()
+	m := wideBitMatrix()
+	w := make(BitMatrix, M)
+	for i := range w {
+		w[i] = new(big.Int)
+	}
+	for b.Loop() {
+		bm := w
+		for i, r := range m.rows {
+			bm[i].Set(r)
+		}
+		sinkRank, _ = bm.RowReducedEcholonForm(0)
+	}
+}
+
+func BenchmarkRankSelect(b *testing.B) {
+	vs := wideVectors()
+	rs := NewRankSelect(vs[M-1])
+	n := rs.OnesCount()
+	for b.Loop() {
+		for i := uint(1); i <= 10000; i += 97 {
+			rs.Rank1(i)
+		}
+		for k := 1; k <= n; k += 97 {
+			rs.Select1(k)
+		}
+	}
+}
+
+func BenchmarkBall(b *testing.B) {
+	b.ReportAllocs()
+	center, _ := ParseGF2Vector(NewGF2VectorSpace(64), "0x0123456789abcdef")
+	var n int
+	for b.Loop() {
+		for v := range center.Ball(2) {
+			n += Distance(center, v)
+		}
+	}
+	sinkDistance = n
+}
+
+// Benchmarks of the multiplication of polynomials of 256 words,
+// by Karatsuba multiplication and schoolbook multiplication.
+
+func benchmarkPolyMul(b *testing.B, threshold int) {
+	defer func(k int) { karatsubaThreshold = k }(karatsubaThreshold)
+	karatsubaThreshold = threshold
+	r := rand.New(rand.NewPCG(1, 2))
+	x, y := randomPoly(r, 256), randomPoly(r, 256)
+	z := new(GF2Poly)
+	for b.Loop() {
+		z.Mul(x, y)
+	}
+}
+
+func BenchmarkPolyMulKaratsuba(b *testing.B) {
+	benchmarkPolyMul(b, karatsubaThreshold)
+}
+
+func BenchmarkPolyMulSchoolbook(b *testing.B) {
+	benchmarkPolyMul(b, 1<<30)
+}
+
+// Benchmarks of the CRC-32C of 64 KiB, byte by byte and by slicing-by-8.
+
+var crcData = make([]byte, 64<<10)
+
+func BenchmarkCRCTable(b *testing.B) {
+	c := NewCRC(CRC32C)
+	b.SetBytes(int64(len(crcData)))
+	for b.Loop() {
+		c.updateTable(0, crcData)
+	}
+}
+
+func BenchmarkCRCSlicing8(b *testing.B) {
+	c := NewCRC(CRC32C)
+	b.SetBytes(int64(len(crcData)))
+	for b.Loop() {
+		c.update(0, crcData)
+	}
+}
+
+// Benchmarks of the product of 4096x4096 matrices by the naive kernel,
+// M4RM and the Strassen–Winograd recursion over M4RM.
+
+func benchmarkMatrixMul(b *testing.B, m4rm, strassen int) {
+	defer func(m, s int) { m4rmThreshold, strassenThreshold = m, s }(m4rmThreshold, strassenThreshold)
+	m4rmThreshold, strassenThreshold = m4rm, strassen
+	r := rand.New(rand.NewPCG(1, 2))
+	x, y := randomMatrix(r, 4096, 4096), randomMatrix(r, 4096, 4096)
+	for b.Loop() {
+		Mul(x, y)
+	}
+}
+
+func BenchmarkMatrixMulNaive(b *testing.B) {
+	benchmarkMatrixMul(b, 1<<30, 1<<30)
+}
+
+func BenchmarkMatrixMulM4RM(b *testing.B) {
+	benchmarkMatrixMul(b, 64, 1<<30)
+}
+
+func BenchmarkMatrixMulStrassen(b *testing.B) {
+	benchmarkMatrixMul(b, 64, 2048)
+}
+
+// Benchmarks of the key stream of 1 KiB of LFSRs of a single word and of
+// a wide register, the state is shifted in place without allocations.
+
+func benchmarkLFSR(b *testing.B, form LFSRForm, p *GF2Poly) {
+	b.ReportAllocs()
+	state := NewGF2VectorSpace(uint(p.Degree())).GF2Ones()
+	r := NewLFSR(form, p, state)
+	buf := make([]byte, 1024)
+	b.SetBytes(int64(len(buf)))
+	for b.Loop() {
+		r.XORKeyStream(buf, buf)
+	}
+}
+
+func BenchmarkLFSRFibonacci31(b *testing.B) {
+	benchmarkLFSR(b, Fibonacci, NewGF2Poly(31, 3, 0))
+}
+
+func BenchmarkLFSRFibonacci127(b *testing.B) {
+	benchmarkLFSR(b, Fibonacci, NewGF2Poly(127, 1, 0))
+}
+
+func BenchmarkLFSRGalois127(b *testing.B) {
+	benchmarkLFSR(b, Galois, NewGF2Poly(127, 1, 0))
+}
@@ -164,7 +164,18 @@ func (e *XorSatSolveError) Error() string {
 // error is set to "Partial Solution returned".
 // Maximum value of mr supported is bits.UintSize - 2
 // Other errors are set on invalid input.
+// The row length is the BitLen of the first row, use a SizedBitMatrix for
+// matrices with leading zero columns.
 func (bm *BitMatrix) SolutionFromRref(mr int) ([]int, error) {
+	rowlen := 0
+	if len(*bm) > 0 {
+		rowlen = (*bm)[0].BitLen()
+	}
+	return bm.solutionFromRref(mr, rowlen)
+}
+
+// solutionFromRref return the solution of SolutionFromRref for rows of rowlen bits.
+func (bm *BitMatrix) solutionFromRref(mr, rowlen int) ([]int, error) {
 	if mr <= 0 {
 		return nil, &XorSatSolveError{"No values on right side"}
 	}
@@ -177,7 +188,6 @@ func (bm *BitMatrix) SolutionFromRref(mr int) ([]int, error) {
 		return nil, &XorSatSolveError{"Given BitMatrix has no elements"}
 	}
 	// check row length, need to be bigger as count of rows + bits on right side
-	rowlen := (*bm)[0].BitLen()
 	if rowlen < mr+ln {
 		msg := fmt.Sprintf("Row length=%v to short, "+
 			"#rows:ln=%v, #right:mr=%v", rowlen, ln, mr)
@@ -0,0 +1,294 @@
+// Ralf Poeppel, 2026
+//
+// This file implements cyclic redundancy checks of width up to 64 bits,
+// parameterised as in the Rocksoft model of Ross N. Williams:
+// width, polynomial, init value, reflection of the input bytes and
+// the output, and the xor of the output.
+// https://reveng.sourceforge.io/crc-catalogue/all.htm
+// The register of a reflected CRC is held reflected and right aligned in a
+// uint64, the register of a not reflected CRC left aligned. The update is
+// table-driven byte by byte, for 8 and more bytes by slicing-by-8.
+// A CRC is linear in the register, appending n zero bytes maps the register r
+// to M^(8n) r, with the matrix M of one zero bit. Combine uses the
+// GF2LinearMap of M and its powers by squaring.
+
+package gf2vs
+
+import (
+	"encoding/binary"
+	"fmt"
+	"hash"
+	"math/big"
+	"math/bits"
+)
+
+// CRCParams the parameters of a CRC in the Rocksoft model.
+// Poly, Init and XorOut are of Width bits, Poly without the term x^Width,
+// the most significant bit is the coefficient of x^(Width-1).
+// Check is the CRC of the ASCII string "123456789", it is not used
+// to compute CRCs.
+type CRCParams struct {
+	Name   string
+	Width  uint
+	Poly   uint64
+	Init   uint64
+	RefIn  bool
+	RefOut bool
+	XorOut uint64
+	Check  uint64
+}
+
+// The parameters of common CRCs from the catalogue of parametrised CRC algorithms.
+var (
+	CRC8SMBus       = CRCParams{"CRC-8/SMBUS", 8, 0x07, 0, false, false, 0, 0xf4}
+	CRC16CCITT      = CRCParams{"CRC-16/KERMIT", 16, 0x1021, 0, true, true, 0, 0x2189}
+	CRC16CCITTFalse = CRCParams{"CRC-16/IBM-3740", 16, 0x1021, 0xffff, false, false, 0, 0x29b1}
+	CRC16XModem     = CRCParams{"CRC-16/XMODEM", 16, 0x1021, 0, false, false, 0, 0x31c3}
+	CRC32           = CRCParams{"CRC-32/ISO-HDLC", 32, 0x04c11db7, 0xffffffff, true, true, 0xffffffff, 0xcbf43926}
+	CRC32BZip2      = CRCParams{"CRC-32/BZIP2", 32, 0x04c11db7, 0xffffffff, false, false, 0xffffffff, 0xfc891918}
+	CRC32C          = CRCParams{"CRC-32/ISCSI", 32, 0x1edc6f41, 0xffffffff, true, true, 0xffffffff, 0xe3069283}
+	CRC64ECMA182    = CRCParams{"CRC-64/ECMA-182", 64, 0x42f0e1eba9ea3693, 0, false, false, 0, 0x6c40df5f0b497347}
+	CRC64XZ         = CRCParams{"CRC-64/XZ", 64, 0x42f0e1eba9ea3693, 1<<64 - 1, true, true, 1<<64 - 1, 0x995dc9bbdf1939fa}
+)
+
+// CRC computes the CRC of the parameters p.
+type CRC struct {
+	p     CRCParams
+	mask  uint64         // Width ones
+	table [8][256]uint64 // table[k][b] the register of byte b followed by k zero bytes
+}
+
+// NewCRC create the CRC of the parameters p.
+// Panic if p is invalid, it is NewCRCErr panicking with its error.
+func NewCRC(p CRCParams) *CRC {
+	c, err := NewCRCErr(p)
+	if err != nil {
+		panic(err.Error())
+	}
+	return c
+}
+
+// NewCRCErr create the CRC of the parameters p.
+// Return an error wrapping ErrValueOutOfRange if p.Width is not in [1, 64]
+// or p.Poly, p.Init or p.XorOut have more than p.Width bits.
+func NewCRCErr(p CRCParams) (*CRC, error) {
+	const op = "NewCRC(p)"
+	if p.Width < 1 || p.Width > 64 {
+		return nil, outOfRange(op, "p.Width = %v not in [1, 64]", p.Width)
+	}
+	c := &CRC{p: p, mask: 1<<p.Width - 1}
+	for _, x := range []struct {
+		name string
+		v    uint64
+	}{{"Poly", p.Poly}, {"Init", p.Init}, {"XorOut", p.XorOut}} {
+		if x.v&^c.mask != 0 {
+			return nil, outOfRange(op, "p.%v = %#x has more than %v bits", x.name, x.v, p.Width)
+		}
+	}
+	c.tables()
+	return c, nil
+}
+
+// reflectBits return the w least significant bits of x in reverse order.
+func reflectBits(x uint64, w uint) uint64 {
+	return bits.Reverse64(x) >> (64 - w)
+}
+
+// tables compute the tables of the byte by byte and slicing-by-8 update.
+func (c *CRC) tables() {
+	t := &c.table
+	if c.p.RefIn {
+		poly := reflectBits(c.p.Poly, c.p.Width)
+		for i := range uint64(256) {
+			r := i
+			for range 8 {
+				r = r>>1 ^ poly&-(r&1)
+			}
+			t[0][i] = r
+		}
+		for k := 1; k < 8; k++ {
+			for i, r := range t[k-1] {
+				t[k][i] = r>>8 ^ t[0][byte(r)]
+			}
+		}
+		return
+	}
+	poly := c.p.Poly << (64 - c.p.Width)
+	for i := range uint64(256) {
+		r := i << 56
+		for range 8 {
+			r = r<<1 ^ poly&-(r>>63)
+		}
+		t[0][i] = r
+	}
+	for k := 1; k < 8; k++ {
+		for i, r := range t[k-1] {
+			t[k][i] = r<<8 ^ t[0][byte(r>>56)]
+		}
+	}
+}
+
+// Params return the parameters of c.
+func (c *CRC) Params() CRCParams {
+	return c.p
+}
+
+func (c *CRC) String() string {
+	return c.p.Name
+}
+
+// register return the internal register of the register value r of the model.
+func (c *CRC) register(r uint64) uint64 {
+	if c.p.RefIn {
+		return reflectBits(r, c.p.Width)
+	}
+	return r << (64 - c.p.Width)
+}
+
+// value return the register value of the model of the internal register reg.
+func (c *CRC) value(reg uint64) uint64 {
+	if c.p.RefIn {
+		return reflectBits(reg, c.p.Width)
+	}
+	return reg >> (64 - c.p.Width)
+}
+
+// finish return the CRC of the register value r of the model.
+func (c *CRC) finish(r uint64) uint64 {
+	if c.p.RefOut {
+		r = reflectBits(r, c.p.Width)
+	}
+	return r ^ c.p.XorOut
+}
+
+// unfinish return the register value of the model of the CRC crc, the inverse of finish.
+func (c *CRC) unfinish(crc uint64) uint64 {
+	r := (crc ^ c.p.XorOut) & c.mask
+	if c.p.RefOut {
+		r = reflectBits(r, c.p.Width)
+	}
+	return r
+}
+
+// updateTable return the internal register reg updated by p byte by byte.
+func (c *CRC) updateTable(reg uint64, p []byte) uint64 {
+	t := &c.table[0]
+	if c.p.RefIn {
+		for _, b := range p {
+			reg = t[byte(reg)^b] ^ reg>>8
+		}
+		return reg
+	}
+	for _, b := range p {
+		reg = t[byte(reg>>56)^b] ^ reg<<8
+	}
+	return reg
+}
+
+// update return the internal register reg updated by p,
+// 8 bytes at once by slicing-by-8.
+func (c *CRC) update(reg uint64, p []byte) uint64 {
+	t := &c.table
+	if c.p.RefIn {
+		for ; len(p) >= 8; p = p[8:] {
+			reg ^= binary.LittleEndian.Uint64(p)
+			reg = t[7][byte(reg)] ^ t[6][byte(reg>>8)] ^
+				t[5][byte(reg>>16)] ^ t[4][byte(reg>>24)] ^
+				t[3][byte(reg>>32)] ^ t[2][byte(reg>>40)] ^
+				t[1][byte(reg>>48)] ^ t[0][byte(reg>>56)]
+		}
+	} else {
+		for ; len(p) >= 8; p = p[8:] {
+			reg ^= binary.BigEndian.Uint64(p)
+			reg = t[7][byte(reg>>56)] ^ t[6][byte(reg>>48)] ^
+				t[5][byte(reg>>40)] ^ t[4][byte(reg>>32)] ^
+				t[3][byte(reg>>24)] ^ t[2][byte(reg>>16)] ^
+				t[1][byte(reg>>8)] ^ t[0][byte(reg)]
+		}
+	}
+	return c.updateTable(reg, p)
+}
+
+// Checksum return the CRC of data.
+func (c *CRC) Checksum(data []byte) uint64 {
+	return c.finish(c.value(c.update(c.register(c.p.Init), data)))
+}
+
+// Update return the CRC of the data with the CRC crc followed by p.
+func (c *CRC) Update(crc uint64, p []byte) uint64 {
+	return c.finish(c.value(c.update(c.register(c.unfinish(crc)), p)))
+}
+
+// zeroBit return the linear map of the register values of the model
+// appending one zero bit: r -> r*x mod Poly.
+func (c *CRC) zeroBit() *GF2LinearMap {
+	sp := NewGF2VectorSpace(c.p.Width)
+	images := make([]*GF2Vector, c.p.Width)
+	for i := range images[:c.p.Width-1] {
+		images[i] = sp.GF2BaseVector(uint(i) + 2)
+	}
+	images[c.p.Width-1] = sp.vectorOf(new(big.Int).SetUint64(c.p.Poly))
+	return NewGF2LinearMapImages(sp, images)
+}
+
+// Combine return the CRC of the concatenation of data A and B
+// of the CRC crcA of A, the CRC crcB of B and the length lenB of B.
+// The register of A is shifted over lenB zero bytes by the power of the
+// matrix of a zero byte, the matrix is squared for each bit of lenB.
+// Panic if lenB is negative.
+func (c *CRC) Combine(crcA, crcB uint64, lenB int64) uint64 {
+	if lenB < 0 {
+		panic(fmt.Sprintf("Combine(crcA, crcB, lenB): lenB = %v is negative", lenB))
+	}
+	// register(A||B) = M^(8 lenB) (register(A) ^ Init) ^ register(B)
+	m := c.zeroBit()
+	for range 3 {
+		m = m.Compose(m)
+	}
+	v := m.Domain().vectorOf(new(big.Int).SetUint64(c.unfinish(crcA) ^ c.p.Init))
+	for n := uint64(lenB); n != 0; n >>= 1 {
+		if n&1 != 0 {
+			v = m.Apply(v)
+		}
+		if n > 1 {
+			m = m.Compose(m)
+		}
+	}
+	r := intOf(v).Uint64() ^ c.unfinish(crcB)
+	return c.finish(r)
+}
+
+// crcDigest the hash.Hash64 of a CRC.
+type crcDigest struct {
+	c   *CRC
+	reg uint64
+}
+
+// New return a new hash.Hash64 computing the CRC c. The Sum of the hash
+// appends the CRC big-endian with the bytes of Width bits.
+func (c *CRC) New() hash.Hash64 {
+	d := &crcDigest{c: c}
+	d.Reset()
+	return d
+}
+
+func (d *crcDigest) Size() int { return int(d.c.p.Width+7) / 8 }
+
+func (d *crcDigest) BlockSize() int { return 1 }
+
+func (d *crcDigest) Reset() { d.reg = d.c.register(d.c.p.Init) }
+
+func (d *crcDigest) Write(p []byte) (n int, err error) {
+	d.reg = d.c.update(d.reg, p)
+	return len(p), nil
+}
+
+func (d *crcDigest) Sum64() uint64 { return d.c.finish(d.c.value(d.reg)) }
+
+func (d *crcDigest) Sum(in []byte) []byte {
+	s := d.Sum64()
+	for i := d.Size() - 1; i >= 0; i-- {
+		in = append(in, byte(s>>(8*i)))
+	}
+	return in
+}
@@ -0,0 +1,128 @@
+// Ralf Poeppel, 2026
+
+package gf2vs
+
+import (
+	"bytes"
+	"errors"
+	"hash/crc32"
+	"hash/crc64"
+	"math/rand/v2"
+	"testing"
+)
+
+// testCRCs the presets and CRCs of unusual widths and reflections.
+var testCRCs = []CRCParams{
+	CRC8SMBus, CRC16CCITT, CRC16CCITTFalse, CRC16XModem,
+	CRC32, CRC32BZip2, CRC32C, CRC64ECMA182, CRC64XZ,
+	{"CRC-3/ROHC", 3, 0x3, 0x7, true, true, 0, 0x6},
+	{"CRC-5/USB", 5, 0x05, 0x1f, true, true, 0x1f, 0x19},
+	{"CRC-12/UMTS", 12, 0x80f, 0, false, true, 0, 0xdaf},
+	{"CRC-24/OPENPGP", 24, 0x864cfb, 0xb704ce, false, false, 0, 0x21cf02},
+	{"CRC-40/GSM", 40, 0x0004820009, 0, false, false, 0xffffffffff, 0xd4164fc646},
+}
+
+func TestCRCCheck(t *testing.T) {
+	for _, p := range testCRCs {
+		c := NewCRC(p)
+		if got := c.Checksum([]byte("123456789")); got != p.Check {
+			t.Errorf("%v: Checksum(123456789) = %#x, want %#x", c, got, p.Check)
+		}
+		if got := c.Checksum(nil); got != c.finish(p.Init) {
+			t.Errorf("%v: Checksum(nil) = %#x, want %#x", c, got, c.finish(p.Init))
+		}
+	}
+}
+
+func TestCRCStdlib(t *testing.T) {
+	r := rand.New(rand.NewPCG(1, 2))
+	c32, c32c, c64 := NewCRC(CRC32), NewCRC(CRC32C), NewCRC(CRC64XZ)
+	castagnoli, ecma := crc32.MakeTable(crc32.Castagnoli), crc64.MakeTable(crc64.ECMA)
+	for range 20 {
+		data := make([]byte, r.IntN(100))
+		for i := range data {
+			data[i] = byte(r.Uint32())
+		}
+		if got, want := c32.Checksum(data), uint64(crc32.ChecksumIEEE(data)); got != want {
+			t.Errorf("CRC-32(%x) = %#x, want %#x", data, got, want)
+		}
+		if got, want := c32c.Checksum(data), uint64(crc32.Checksum(data, castagnoli)); got != want {
+			t.Errorf("CRC-32C(%x) = %#x, want %#x", data, got, want)
+		}
+		if got, want := c64.Checksum(data), crc64.Checksum(data, ecma); got != want {
+			t.Errorf("CRC-64/XZ(%x) = %#x, want %#x", data, got, want)
+		}
+	}
+}
+
+func TestCRCUpdateCombine(t *testing.T) {
+	r := rand.New(rand.NewPCG(1, 2))
+	for _, p := range testCRCs {
+		c := NewCRC(p)
+		for range 10 {
+			data := make([]byte, r.IntN(200))
+			for i := range data {
+				data[i] = byte(r.Uint32())
+			}
+			want := c.Checksum(data)
+			if got := c.finish(c.value(c.updateTable(c.register(p.Init), data))); got != want {
+				t.Errorf("%v: updateTable(%x) = %#x, want %#x", c, data, got, want)
+			}
+			k := r.IntN(len(data) + 1)
+			a, b := data[:k], data[k:]
+			if got := c.Update(c.Checksum(a), b); got != want {
+				t.Errorf("%v: Update(Checksum(%x), %x) = %#x, want %#x", c, a, b, got, want)
+			}
+			if got := c.Combine(c.Checksum(a), c.Checksum(b), int64(len(b))); got != want {
+				t.Errorf("%v: Combine(%x, %x) = %#x, want %#x", c, a, b, got, want)
+			}
+		}
+	}
+}
+
+func TestCRCCombineLong(t *testing.T) {
+	c := NewCRC(CRC64XZ)
+	a, b := []byte("123456789"), make([]byte, 1<<20+3)
+	want := c.Checksum(append(a, b...))
+	if got := c.Combine(c.Checksum(a), c.Checksum(b), int64(len(b))); got != want {
+		t.Errorf("Combine(123456789, %v zero bytes) = %#x, want %#x", len(b), got, want)
+	}
+}
+
+func TestCRCHash(t *testing.T) {
+	h := NewCRC(CRC32C).New()
+	h.Write([]byte("12345"))
+	h.Write([]byte("6789"))
+	if got := h.Sum64(); got != CRC32C.Check {
+		t.Errorf("Sum64() = %#x, want %#x", got, CRC32C.Check)
+	}
+	if got, want := h.Sum([]byte{0}), []byte{0, 0xe3, 0x06, 0x92, 0x83}; !bytes.Equal(got, want) {
+		t.Errorf("Sum(0) = %x, want %x", got, want)
+	}
+	if h.Size() != 4 || h.BlockSize() != 1 {
+		t.Errorf("Size() = %v, BlockSize() = %v", h.Size(), h.BlockSize())
+	}
+	h.Reset()
+	h.Write([]byte("123456789"))
+	if got := h.Sum64(); got != CRC32C.Check {
+		t.Errorf("Sum64() after Reset = %#x, want %#x", got, CRC32C.Check)
+	}
+	if got := NewCRC(testCRCs[11]).New().Size(); got != 2 {
+		t.Errorf("CRC-12/UMTS Size() = %v, want 2", got)
+	}
+}
+
+func TestNewCRCErr(t *testing.T) {
+	cases := []CRCParams{
+		{Width: 0},
+		{Width: 65},
+		{Width: 8, Poly: 0x107},
+		{Width: 8, Poly: 0x07, Init: 0x100},
+		{Width: 16, Poly: 0x1021, XorOut: 0x10000},
+	}
+	for _, p := range cases {
+		if _, err := NewCRCErr(p); !errors.Is(err, ErrValueOutOfRange) {
+			t.Errorf("NewCRCErr(%+v) error = %v, want %v", p, err, ErrValueOutOfRange)
+		}
+	}
+}
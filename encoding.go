@@ -0,0 +1,712 @@
+// Ralf Poeppel, 2026
+//
+// This file implements the encodings of vector spaces, vectors and bit matrices.
+// They implement encoding.BinaryMarshaler, encoding.TextMarshaler and
+// json.Marshaler with the corresponding unmarshalers.
+// All encodings are versioned and hold the type and the dimensions.
+//
+// Binary: version byte, type byte, dimensions as uvarint, values as bytes
+// least significant byte first, ceil(dim/8) bytes for each vector or row.
+//
+// Text: "gf2vs.<type>/v<version>" followed by the dimensions and the values
+// as binary strings separated by spaces, e.g. "gf2vs.vector/v1 3 101".
+//
+// JSON: an object with version, type, dimensions and the values as
+// binary strings, e.g. {"version":1,"type":"vector","dim":3,"value":"101"}.
+
+package gf2vs
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// encodingVersion the version of all encodings.
+const encodingVersion = 1
+
+// The types of the encodings.
+const (
+	typeSpace    = "space"
+	typeSubspace = "subspace"
+	typeVector   = "vector"
+	typeMatrix   = "matrix"
+)
+
+// typeByte the type byte of the binary encoding.
+var typeByte = map[string]byte{typeSpace: 'S', typeSubspace: 'U', typeVector: 'V', typeMatrix: 'M'}
+
+// invalid return a VectorSpaceError wrapping ErrInvalidEncoding.
+func invalid(op string, format string, a ...any) error {
+	return &VectorSpaceError{op, fmt.Sprintf(format, a...), ErrInvalidEncoding}
+}
+
+// AugmentedBitMatrix is an extended coefficient matrix [A | B] of a system
+// of xor equations as used by XorSatSolve, the Right rightmost columns hold
+// the right sides B. Its encodings hold the count of right columns.
+type AugmentedBitMatrix struct {
+	BitMatrix
+	Right int // count of columns on the right side
+}
+
+// cols return the count of columns of bm, the largest BitLen of the rows,
+// at least right and at least 1 for a matrix with rows.
+func (bm *BitMatrix) cols(right int) int {
+	c := right
+	if len(*bm) > 0 {
+		c = max(c, 1)
+	}
+	for _, row := range *bm {
+		c = max(c, row.BitLen())
+	}
+	return c
+}
+
+// byteLen return the count of bytes of a vector of n bits.
+func byteLen(n uint) int {
+	return int((n + 7) / 8)
+}
+
+// appendBytes append the n bytes of x to b, least significant byte first.
+func appendBytes(b []byte, x *big.Int, n int) []byte {
+	buf := make([]byte, n)
+	x.FillBytes(buf)
+	for i := n - 1; i >= 0; i-- {
+		b = append(b, buf[i])
+	}
+	return b
+}
+
+// intFromBytes return the value of the bytes b, least significant byte first.
+// Return false if a bit at position n or above is set.
+func intFromBytes(b []byte, n uint) (*big.Int, bool) {
+	buf := make([]byte, len(b))
+	for i, c := range b {
+		buf[len(b)-1-i] = c
+	}
+	x := new(big.Int).SetBytes(buf)
+	return x, uint(x.BitLen()) <= n
+}
+
+// binaryText return the binary string of x with n digits.
+func binaryText(x *big.Int, n int) string {
+	s := x.Text(2)
+	if x.Sign() == 0 {
+		s = ""
+	}
+	return strings.Repeat("0", n-len(s)) + s
+}
+
+// intFromText return the value of the binary string s with n digits.
+func intFromText(s string, n int) (*big.Int, bool) {
+	if len(s) != n || strings.Trim(s, "01") != "" {
+		return nil, false
+	}
+	if n == 0 {
+		return new(big.Int), true
+	}
+	return new(big.Int).SetString(s, 2)
+}
+
+// header parse the version and type of a binary encoding.
+func header(op string, data []byte, typ string) ([]byte, error) {
+	if len(data) < 2 {
+		return nil, invalid(op, "data too short")
+	}
+	if data[0] != encodingVersion {
+		return nil, invalid(op, "version %v not supported", data[0])
+	}
+	if data[1] != typeByte[typ] {
+		return nil, invalid(op, "type %q is not %v", data[1], typ)
+	}
+	return data[2:], nil
+}
+
+// uvarints parse n uvarints of data and return them and the remaining data.
+func uvarints(op string, data []byte, n int) ([]uint64, []byte, error) {
+	u := make([]uint64, n)
+	for i := range u {
+		x, k := binary.Uvarint(data)
+		if k <= 0 {
+			return nil, nil, invalid(op, "invalid dimension")
+		}
+		u[i] = x
+		data = data[k:]
+	}
+	return u, data, nil
+}
+
+// textHeader split a text encoding in fields and check version and type,
+// n is the count of fields following the header.
+func textHeader(op string, text []byte, typ string, n int) ([]string, error) {
+	f := strings.Fields(string(text))
+	want := fmt.Sprintf("gf2vs.%v/v%v", typ, encodingVersion)
+	if len(f) == 0 || f[0] != want {
+		return nil, invalid(op, "header is not %v", want)
+	}
+	if n >= 0 && len(f) != n+1 {
+		return nil, invalid(op, "%v fields, want %v", len(f)-1, n)
+	}
+	return f[1:], nil
+}
+
+// jsonEncoding the JSON object of all types.
+type jsonEncoding struct {
+	Version int      `json:"version"`
+	Type    string   `json:"type"`
+	Dim     uint     `json:"dim,omitempty"`
+	Value   string   `json:"value,omitempty"`
+	Base    *string  `json:"base,omitempty"`
+	Rows    *int     `json:"rows,omitempty"`
+	Cols    int      `json:"cols,omitempty"`
+	Right   int      `json:"right,omitempty"`
+	Data    []string `json:"data,omitempty"`
+}
+
+// unmarshalJSON parse data and check version and type.
+func unmarshalJSON(data []byte, typ string) (*jsonEncoding, error) {
+	var e jsonEncoding
+	if err := json.Unmarshal(data, &e); err != nil {
+		return nil, &VectorSpaceError{"UnmarshalJSON", err.Error(), ErrInvalidEncoding}
+	}
+	if e.Version != encodingVersion {
+		return nil, invalid("UnmarshalJSON", "version %v not supported", e.Version)
+	}
+	if e.Type != typ {
+		return nil, invalid("UnmarshalJSON", "type %q is not %v", e.Type, typ)
+	}
+	return &e, nil
+}
+
+// newSpace return the vector space of dimension dim for unmarshaling.
+func newSpace(op string, dim uint64) (*GF2VectorSpace, error) {
+	if dim < 1 || dim != uint64(uint(dim)) {
+		return nil, invalid(op, "dim = %v out of range", dim)
+	}
+	return NewGF2VectorSpaceErr(uint(dim))
+}
+
+// GF2VectorSpace
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+func (s *GF2VectorSpace) MarshalBinary() ([]byte, error) {
+	b := []byte{encodingVersion, typeByte[typeSpace]}
+	return binary.AppendUvarint(b, uint64(s.dim)), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (s *GF2VectorSpace) UnmarshalBinary(data []byte) error {
+	const op = "UnmarshalBinary"
+	data, err := header(op, data, typeSpace)
+	if err != nil {
+		return err
+	}
+	u, data, err := uvarints(op, data, 1)
+	if err != nil {
+		return err
+	}
+	if len(data) != 0 {
+		return invalid(op, "%v bytes too many", len(data))
+	}
+	sp, err := newSpace(op, u[0])
+	if err != nil {
+		return err
+	}
+	*s = *sp
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s *GF2VectorSpace) MarshalText() ([]byte, error) {
+	return fmt.Appendf(nil, "gf2vs.%v/v%v %v", typeSpace, encodingVersion, s.dim), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *GF2VectorSpace) UnmarshalText(text []byte) error {
+	const op = "UnmarshalText"
+	f, err := textHeader(op, text, typeSpace, 1)
+	if err != nil {
+		return err
+	}
+	dim, err := strconv.ParseUint(f[0], 10, 64)
+	if err != nil {
+		return invalid(op, "dim = %q", f[0])
+	}
+	sp, err := newSpace(op, dim)
+	if err != nil {
+		return err
+	}
+	*s = *sp
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s *GF2VectorSpace) MarshalJSON() ([]byte, error) {
+	return json.Marshal(jsonEncoding{Version: encodingVersion, Type: typeSpace, Dim: s.dim})
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *GF2VectorSpace) UnmarshalJSON(data []byte) error {
+	e, err := unmarshalJSON(data, typeSpace)
+	if err != nil {
+		return err
+	}
+	sp, err := newSpace("UnmarshalJSON", uint64(e.Dim))
+	if err != nil {
+		return err
+	}
+	*s = *sp
+	return nil
+}
+
+// GF2Vector
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+func (v *GF2Vector) MarshalBinary() ([]byte, error) {
+	b := []byte{encodingVersion, typeByte[typeVector]}
+	b = binary.AppendUvarint(b, uint64(v.sp.dim))
+	return appendBytes(b, intOf(v), byteLen(v.sp.dim)), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+// The vector is of a new vector space of the encoded dimension.
+func (v *GF2Vector) UnmarshalBinary(data []byte) error {
+	const op = "UnmarshalBinary"
+	data, err := header(op, data, typeVector)
+	if err != nil {
+		return err
+	}
+	u, data, err := uvarints(op, data, 1)
+	if err != nil {
+		return err
+	}
+	if u[0] > uint64(len(data))*8 {
+		return invalid(op, "dim = %v out of range", u[0])
+	}
+	sp, err := newSpace(op, u[0])
+	if err != nil {
+		return err
+	}
+	if len(data) != byteLen(sp.dim) {
+		return invalid(op, "%v bytes, want %v", len(data), byteLen(sp.dim))
+	}
+	x, ok := intFromBytes(data, sp.dim)
+	if !ok {
+		return invalid(op, "value has more than %v bits", sp.dim)
+	}
+	*v = *sp.vectorOf(x)
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (v *GF2Vector) MarshalText() ([]byte, error) {
+	return fmt.Appendf(nil, "gf2vs.%v/v%v %v %v", typeVector, encodingVersion, v.sp.dim, v), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+// The vector is of a new vector space of the encoded dimension.
+func (v *GF2Vector) UnmarshalText(text []byte) error {
+	const op = "UnmarshalText"
+	f, err := textHeader(op, text, typeVector, 2)
+	if err != nil {
+		return err
+	}
+	dim, err := strconv.ParseUint(f[0], 10, 64)
+	if err != nil || dim != uint64(len(f[1])) {
+		return invalid(op, "dim = %q does not match value", f[0])
+	}
+	return v.setText(op, dim, f[1])
+}
+
+// setText set v to the value of the binary string s of a new vector space
+// of dimension dim.
+func (v *GF2Vector) setText(op string, dim uint64, s string) error {
+	sp, err := newSpace(op, dim)
+	if err != nil {
+		return err
+	}
+	x, ok := intFromText(s, int(sp.dim))
+	if !ok {
+		return invalid(op, "value %q is no binary string of %v digits", s, sp.dim)
+	}
+	*v = *sp.vectorOf(x)
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler.
+func (v *GF2Vector) MarshalJSON() ([]byte, error) {
+	return json.Marshal(jsonEncoding{Version: encodingVersion, Type: typeVector,
+		Dim: v.sp.dim, Value: v.String()})
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+// The vector is of a new vector space of the encoded dimension.
+func (v *GF2Vector) UnmarshalJSON(data []byte) error {
+	e, err := unmarshalJSON(data, typeVector)
+	if err != nil {
+		return err
+	}
+	return v.setText("UnmarshalJSON", uint64(e.Dim), e.Value)
+}
+
+// GF2SubVectorSpace
+
+// subVector return the vector of the base bits of sp.
+func (sp *GF2SubVectorSpace) subVector() *GF2Vector {
+	if sp.isWide() {
+		return &GF2Vector{sp: &sp.GF2VectorSpace, words: sp.subWords}
+	}
+	return &GF2Vector{sp: &sp.GF2VectorSpace, val: sp.subOnes}
+}
+
+// setSubVector set sp to the sub vector space with the base bits of v.
+func (sp *GF2SubVectorSpace) setSubVector(v *GF2Vector) {
+	*sp = GF2SubVectorSpace{*v.sp, v.val, v.words}
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+func (sp *GF2SubVectorSpace) MarshalBinary() ([]byte, error) {
+	b, err := sp.subVector().MarshalBinary()
+	b[1] = typeByte[typeSubspace]
+	return b, err
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (sp *GF2SubVectorSpace) UnmarshalBinary(data []byte) error {
+	if _, err := header("UnmarshalBinary", data, typeSubspace); err != nil {
+		return err
+	}
+	var v GF2Vector
+	if err := v.UnmarshalBinary(append([]byte{data[0], typeByte[typeVector]}, data[2:]...)); err != nil {
+		return err
+	}
+	sp.setSubVector(&v)
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (sp *GF2SubVectorSpace) MarshalText() ([]byte, error) {
+	return fmt.Appendf(nil, "gf2vs.%v/v%v %v %v", typeSubspace, encodingVersion, sp.dim, sp.subVector()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (sp *GF2SubVectorSpace) UnmarshalText(text []byte) error {
+	const op = "UnmarshalText"
+	f, err := textHeader(op, text, typeSubspace, 2)
+	if err != nil {
+		return err
+	}
+	dim, err := strconv.ParseUint(f[0], 10, 64)
+	if err != nil || dim != uint64(len(f[1])) {
+		return invalid(op, "dim = %q does not match base", f[0])
+	}
+	var v GF2Vector
+	if err := v.setText(op, dim, f[1]); err != nil {
+		return err
+	}
+	sp.setSubVector(&v)
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler.
+func (sp *GF2SubVectorSpace) MarshalJSON() ([]byte, error) {
+	base := sp.subVector().String()
+	return json.Marshal(jsonEncoding{Version: encodingVersion, Type: typeSubspace,
+		Dim: sp.dim, Base: &base})
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (sp *GF2SubVectorSpace) UnmarshalJSON(data []byte) error {
+	e, err := unmarshalJSON(data, typeSubspace)
+	if err != nil {
+		return err
+	}
+	if e.Base == nil {
+		return invalid("UnmarshalJSON", "base missing")
+	}
+	var v GF2Vector
+	if err := v.setText("UnmarshalJSON", uint64(e.Dim), *e.Base); err != nil {
+		return err
+	}
+	sp.setSubVector(&v)
+	return nil
+}
+
+// BitMatrix, AugmentedBitMatrix and SizedBitMatrix
+
+// marshalBinary return the binary encoding of bm of cols columns with right
+// columns on the right side.
+func (bm *BitMatrix) marshalBinary(cols, right int) []byte {
+	b := []byte{encodingVersion, typeByte[typeMatrix]}
+	b = binary.AppendUvarint(b, uint64(len(*bm)))
+	b = binary.AppendUvarint(b, uint64(cols))
+	b = binary.AppendUvarint(b, uint64(right))
+	for _, row := range *bm {
+		b = appendBytes(b, row, byteLen(uint(cols)))
+	}
+	return b
+}
+
+// unmarshalBinary parse the binary encoding of a matrix,
+// return the matrix and the count of right columns.
+func unmarshalBinary(data []byte) (*SizedBitMatrix, int, error) {
+	const op = "UnmarshalBinary"
+	data, err := header(op, data, typeMatrix)
+	if err != nil {
+		return nil, 0, err
+	}
+	u, data, err := uvarints(op, data, 3)
+	if err != nil {
+		return nil, 0, err
+	}
+	rows, cols, right := u[0], u[1], u[2]
+	if right > cols || cols > math.MaxInt32 || rows > 0 && cols > uint64(len(data))*8 {
+		return nil, 0, invalid(op, "cols = %v, right = %v out of range", cols, right)
+	}
+	n := byteLen(uint(cols))
+	// rows <= len(data)/n before the product, so it does not overflow
+	if n == 0 && rows > 0 || n > 0 && (rows > uint64(len(data))/uint64(n) || rows*uint64(n) != uint64(len(data))) {
+		return nil, 0, invalid(op, "%v bytes, want %v rows of %v bytes", len(data), rows, n)
+	}
+	bm := make(BitMatrix, rows)
+	for i := range bm {
+		var ok bool
+		if bm[i], ok = intFromBytes(data[i*n:(i+1)*n], uint(cols)); !ok {
+			return nil, 0, invalid(op, "row %v has more than %v bits", i, cols)
+		}
+	}
+	return &SizedBitMatrix{bm, int(cols)}, int(right), nil
+}
+
+// marshalText return the text encoding of bm of cols columns with right
+// columns on the right side.
+func (bm *BitMatrix) marshalText(cols, right int) []byte {
+	b := fmt.Appendf(nil, "gf2vs.%v/v%v %v %v %v", typeMatrix, encodingVersion, len(*bm), cols, right)
+	for _, row := range *bm {
+		b = append(b, ' ')
+		b = append(b, binaryText(row, cols)...)
+	}
+	return b
+}
+
+// matrixFromText return the matrix of rows given as binary strings with cols digits.
+func matrixFromText(op string, data []string, rows, cols, right int) (*SizedBitMatrix, int, error) {
+	if rows < 0 || cols < 0 || right < 0 || right > cols || len(data) != rows {
+		return nil, 0, invalid(op, "rows = %v, cols = %v, right = %v do not match %v rows",
+			rows, cols, right, len(data))
+	}
+	bm := make(BitMatrix, rows)
+	for i, s := range data {
+		var ok bool
+		if bm[i], ok = intFromText(s, cols); !ok {
+			return nil, 0, invalid(op, "row %v %q is no binary string of %v digits", i, s, cols)
+		}
+	}
+	return &SizedBitMatrix{bm, cols}, right, nil
+}
+
+// unmarshalText parse the text encoding of a matrix,
+// return the matrix and the count of right columns.
+func unmarshalText(text []byte) (*SizedBitMatrix, int, error) {
+	const op = "UnmarshalText"
+	f, err := textHeader(op, text, typeMatrix, -1)
+	if err != nil {
+		return nil, 0, err
+	}
+	if len(f) < 3 {
+		return nil, 0, invalid(op, "dimensions missing")
+	}
+	var dims [3]int
+	for i := range dims {
+		if dims[i], err = strconv.Atoi(f[i]); err != nil {
+			return nil, 0, invalid(op, "dimension %q", f[i])
+		}
+	}
+	return matrixFromText(op, f[3:], dims[0], dims[1], dims[2])
+}
+
+// marshalJSON return the JSON encoding of bm of cols columns with right
+// columns on the right side.
+func (bm *BitMatrix) marshalJSON(cols, right int) ([]byte, error) {
+	rows := len(*bm)
+	data := make([]string, rows)
+	for i, row := range *bm {
+		data[i] = binaryText(row, cols)
+	}
+	return json.Marshal(jsonEncoding{Version: encodingVersion, Type: typeMatrix,
+		Rows: &rows, Cols: cols, Right: right, Data: data})
+}
+
+// unmarshalMatrixJSON parse the JSON encoding of a matrix,
+// return the matrix and the count of right columns.
+func unmarshalMatrixJSON(data []byte) (*SizedBitMatrix, int, error) {
+	e, err := unmarshalJSON(data, typeMatrix)
+	if err != nil {
+		return nil, 0, err
+	}
+	if e.Rows == nil {
+		return nil, 0, invalid("UnmarshalJSON", "rows missing")
+	}
+	return matrixFromText("UnmarshalJSON", e.Data, *e.Rows, e.Cols, e.Right)
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+// The count of right columns is 0. The Marshal methods have value receivers,
+// so a BitMatrix value and a field of type BitMatrix are encoded as well,
+// the Unmarshal methods pointer receivers.
+func (bm BitMatrix) MarshalBinary() ([]byte, error) {
+	return bm.marshalBinary(bm.cols(0), 0), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+// The count of right columns is ignored.
+func (bm *BitMatrix) UnmarshalBinary(data []byte) error {
+	m, _, err := unmarshalBinary(data)
+	if err == nil {
+		*bm = m.rows
+	}
+	return err
+}
+
+// MarshalText implements encoding.TextMarshaler.
+// The count of right columns is 0.
+func (bm BitMatrix) MarshalText() ([]byte, error) {
+	return bm.marshalText(bm.cols(0), 0), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+// The count of right columns is ignored.
+func (bm *BitMatrix) UnmarshalText(text []byte) error {
+	m, _, err := unmarshalText(text)
+	if err == nil {
+		*bm = m.rows
+	}
+	return err
+}
+
+// MarshalJSON implements json.Marshaler.
+// The count of right columns is 0.
+func (bm BitMatrix) MarshalJSON() ([]byte, error) {
+	return bm.marshalJSON(bm.cols(0), 0)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+// The count of right columns is ignored.
+func (bm *BitMatrix) UnmarshalJSON(data []byte) error {
+	m, _, err := unmarshalMatrixJSON(data)
+	if err == nil {
+		*bm = m.rows
+	}
+	return err
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+func (am AugmentedBitMatrix) MarshalBinary() ([]byte, error) {
+	return am.marshalBinary(am.cols(am.Right), am.Right), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (am *AugmentedBitMatrix) UnmarshalBinary(data []byte) error {
+	m, right, err := unmarshalBinary(data)
+	if err == nil {
+		*am = AugmentedBitMatrix{m.rows, right}
+	}
+	return err
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (am AugmentedBitMatrix) MarshalText() ([]byte, error) {
+	return am.marshalText(am.cols(am.Right), am.Right), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (am *AugmentedBitMatrix) UnmarshalText(text []byte) error {
+	m, right, err := unmarshalText(text)
+	if err == nil {
+		*am = AugmentedBitMatrix{m.rows, right}
+	}
+	return err
+}
+
+// MarshalJSON implements json.Marshaler.
+func (am AugmentedBitMatrix) MarshalJSON() ([]byte, error) {
+	return am.marshalJSON(am.cols(am.Right), am.Right)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (am *AugmentedBitMatrix) UnmarshalJSON(data []byte) error {
+	m, right, err := unmarshalMatrixJSON(data)
+	if err == nil {
+		*am = AugmentedBitMatrix{m.rows, right}
+	}
+	return err
+}
+
+// zeroCols return an error wrapping ErrValueOutOfRange if m has rows of
+// 0 columns, their rows are empty in the binary and text encoding.
+func (m *SizedBitMatrix) zeroCols(op string) error {
+	if m.cols == 0 && len(m.rows) > 0 {
+		return outOfRange(op, "%v rows of 0 columns", len(m.rows))
+	}
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+// The encoding holds Rows and Cols, the count of right columns is 0.
+// Return an error wrapping ErrValueOutOfRange if m has rows of 0 columns.
+func (m SizedBitMatrix) MarshalBinary() ([]byte, error) {
+	if err := m.zeroCols("MarshalBinary"); err != nil {
+		return nil, err
+	}
+	return m.rows.marshalBinary(m.cols, 0), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+// The count of right columns is ignored.
+func (m *SizedBitMatrix) UnmarshalBinary(data []byte) error {
+	sm, _, err := unmarshalBinary(data)
+	if err == nil {
+		*m = *sm
+	}
+	return err
+}
+
+// MarshalText implements encoding.TextMarshaler.
+// The encoding holds Rows and Cols, the count of right columns is 0.
+// Return an error wrapping ErrValueOutOfRange if m has rows of 0 columns.
+func (m SizedBitMatrix) MarshalText() ([]byte, error) {
+	if err := m.zeroCols("MarshalText"); err != nil {
+		return nil, err
+	}
+	return m.rows.marshalText(m.cols, 0), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+// The count of right columns is ignored.
+func (m *SizedBitMatrix) UnmarshalText(text []byte) error {
+	sm, _, err := unmarshalText(text)
+	if err == nil {
+		*m = *sm
+	}
+	return err
+}
+
+// MarshalJSON implements json.Marshaler.
+// The encoding holds Rows and Cols, the count of right columns is 0.
+func (m SizedBitMatrix) MarshalJSON() ([]byte, error) {
+	return m.rows.marshalJSON(m.cols, 0)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+// The count of right columns is ignored.
+func (m *SizedBitMatrix) UnmarshalJSON(data []byte) error {
+	sm, _, err := unmarshalMatrixJSON(data)
+	if err == nil {
+		*m = *sm
+	}
+	return err
+}
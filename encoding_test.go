@@ -0,0 +1,230 @@
+// Ralf Poeppel, 2026
+
+package gf2vs
+
+import (
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"strings"
+	"testing"
+)
+
+// roundTrip marshal x with all three encodings and unmarshal into new values
+// created by fresh, return the String of the decoded values.
+func roundTrip[T interface {
+	encoding.BinaryMarshaler
+	encoding.BinaryUnmarshaler
+	encoding.TextMarshaler
+	encoding.TextUnmarshaler
+	json.Marshaler
+	json.Unmarshaler
+}](t *testing.T, x T, fresh func() T, str func(T) string) {
+	t.Helper()
+	want := str(x)
+	b, err := x.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary(%v) error %v", want, err)
+	}
+	y := fresh()
+	if err := y.UnmarshalBinary(b); err != nil || str(y) != want {
+		t.Errorf("UnmarshalBinary(MarshalBinary(%v)) = %v, %v", want, str(y), err)
+	}
+	text, _ := x.MarshalText()
+	y = fresh()
+	if err := y.UnmarshalText(text); err != nil || str(y) != want {
+		t.Errorf("UnmarshalText(%s) = %v, %v, want %v", text, str(y), err, want)
+	}
+	js, _ := json.Marshal(x)
+	y = fresh()
+	if err := json.Unmarshal(js, y); err != nil || str(y) != want {
+		t.Errorf("json.Unmarshal(%s) = %v, %v, want %v", js, str(y), err, want)
+	}
+}
+
+func TestEncodingRoundTrip(t *testing.T) {
+	r := rand.New(rand.NewPCG(1, 2))
+	for _, dim := range []uint{1, 3, 8, 9, 63, 64, 65, 130} {
+		s := NewGF2VectorSpace(dim)
+		roundTrip(t, s, func() *GF2VectorSpace { return new(GF2VectorSpace) },
+			func(s *GF2VectorSpace) string { return s.String() })
+		for range 5 {
+			v := randomVector(r, s, 2)
+			roundTrip(t, v, func() *GF2Vector { return new(GF2Vector) },
+				func(v *GF2Vector) string { return v.String() + v.sp.String() })
+			sp := new(GF2SubVectorSpace)
+			sp.setSubVector(v)
+			roundTrip(t, sp, func() *GF2SubVectorSpace { return new(GF2SubVectorSpace) },
+				func(sp *GF2SubVectorSpace) string { return sp.String() + sp.subVector().String() })
+		}
+	}
+	matrices := []BitMatrix{{}, bitMatrix(0, 0), bitMatrix(5, 3, 1), wideBitMatrix().BitMatrix()}
+	for _, m := range matrices {
+		roundTrip(t, &m, func() *BitMatrix { return new(BitMatrix) },
+			func(m *BitMatrix) string { return "[" + m.Text(2, ",") + "]" })
+		am := &AugmentedBitMatrix{m, 1}
+		roundTrip(t, am, func() *AugmentedBitMatrix { return new(AugmentedBitMatrix) },
+			func(am *AugmentedBitMatrix) string { return am.Text(2, ",") + "|" + string(rune('0'+am.Right)) })
+	}
+	// the shape is kept, leading zero columns and zero rows as well
+	sized := []*SizedBitMatrix{NewBitMatrix(0, 0), NewBitMatrix(0, 4), NewBitMatrix(3, 5),
+		FromStrings([]string{"001", "010"}), FromStrings([]string{"000", "110", "000"}), randomMatrix(r, 7, 130)}
+	for _, m := range sized {
+		roundTrip(t, m, func() *SizedBitMatrix { return new(SizedBitMatrix) },
+			func(m *SizedBitMatrix) string { return fmt.Sprintf("%v x %v %q", m.Rows(), m.Cols(), m) })
+	}
+}
+
+func TestEncodingFormat(t *testing.T) {
+	s := NewGF2VectorSpace(3)
+	sp := NewGF2SubVectorSpace(4, 3)
+	am := &AugmentedBitMatrix{bitMatrix(5, 2), 1}
+	cases := []struct {
+		x      encoding.TextMarshaler
+		text   string
+		json   string
+		binary string
+	}{
+		{s, "gf2vs.space/v1 3", `{"version":1,"type":"space","dim":3}`, "\x01S\x03"},
+		{s.NewGF2Vector(5), "gf2vs.vector/v1 3 101",
+			`{"version":1,"type":"vector","dim":3,"value":"101"}`, "\x01V\x03\x05"},
+		{sp, "gf2vs.subspace/v1 4 0011",
+			`{"version":1,"type":"subspace","dim":4,"base":"0011"}`, "\x01U\x04\x03"},
+		{&am.BitMatrix, "gf2vs.matrix/v1 2 3 0 101 010",
+			`{"version":1,"type":"matrix","rows":2,"cols":3,"data":["101","010"]}`,
+			"\x01M\x02\x03\x00\x05\x02"},
+		{FromStrings([]string{"001", "000"}), "gf2vs.matrix/v1 2 3 0 001 000",
+			`{"version":1,"type":"matrix","rows":2,"cols":3,"data":["001","000"]}`,
+			"\x01M\x02\x03\x00\x01\x00"},
+		{am, "gf2vs.matrix/v1 2 3 1 101 010",
+			`{"version":1,"type":"matrix","rows":2,"cols":3,"right":1,"data":["101","010"]}`,
+			"\x01M\x02\x03\x01\x05\x02"},
+	}
+	for _, c := range cases {
+		text, _ := c.x.MarshalText()
+		if string(text) != c.text {
+			t.Errorf("MarshalText() = %q, want %q", text, c.text)
+		}
+		js, _ := json.Marshal(c.x)
+		if string(js) != c.json {
+			t.Errorf("json.Marshal() = %s, want %s", js, c.json)
+		}
+		b, _ := c.x.(encoding.BinaryMarshaler).MarshalBinary()
+		if string(b) != c.binary {
+			t.Errorf("MarshalBinary() = %q, want %q", b, c.binary)
+		}
+	}
+}
+
+func TestUnmarshalInvalid(t *testing.T) {
+	cases := []struct {
+		x    any
+		data string
+	}{
+		{new(GF2VectorSpace), "\x02S\x03"},
+		{new(GF2VectorSpace), "\x01V\x03"},
+		{new(GF2VectorSpace), "\x01S\x00"},
+		{new(GF2VectorSpace), "\x01S\x03\x00"},
+		{new(GF2VectorSpace), "\x01"},
+		{new(GF2Vector), "\x01V\x03\x08"},
+		{new(GF2Vector), "\x01V\x03\x05\x00"},
+		{new(GF2Vector), "\x01V\x80"},
+		{new(GF2SubVectorSpace), "\x01V\x03\x05"},
+		{new(BitMatrix), "\x01M\x02\x03\x04\x05\x02"},
+		{new(BitMatrix), "\x01M\x02\x03\x00\x05"},
+		{new(BitMatrix), "\x01M\x01\x03\x00\x08"},
+		{new(AugmentedBitMatrix), "\x01M\x01\x00\x00"},
+		// rows = 2^63+1 of 2 bytes, the count of bytes overflows to 2
+		{new(BitMatrix), "\x01M\x81\x80\x80\x80\x80\x80\x80\x80\x80\x01\x09\x00\xff\x01"},
+	}
+	for _, c := range cases {
+		err := c.x.(encoding.BinaryUnmarshaler).UnmarshalBinary([]byte(c.data))
+		if !errors.Is(err, ErrInvalidEncoding) {
+			t.Errorf("UnmarshalBinary(%q) = %v, want ErrInvalidEncoding", c.data, err)
+		}
+	}
+	texts := []struct {
+		x    any
+		text string
+	}{
+		{new(GF2VectorSpace), "gf2vs.space/v2 3"},
+		{new(GF2VectorSpace), "gf2vs.space/v1 0"},
+		{new(GF2VectorSpace), "gf2vs.space/v1 3 4"},
+		{new(GF2VectorSpace), "gf2vs.space/v1 x"},
+		{new(GF2Vector), "gf2vs.vector/v1 3 1010"},
+		{new(GF2Vector), "gf2vs.vector/v1 3 102"},
+		{new(GF2Vector), "gf2vs.space/v1 3"},
+		{new(GF2SubVectorSpace), "gf2vs.subspace/v1 2 012"},
+		{new(BitMatrix), "gf2vs.matrix/v1 2 3 0 101"},
+		{new(BitMatrix), "gf2vs.matrix/v1 1 3 4 101"},
+		{new(BitMatrix), "gf2vs.matrix/v1 1 3 0 1010"},
+		{new(BitMatrix), "gf2vs.matrix/v1 1 3"},
+		{new(AugmentedBitMatrix), "gf2vs.matrix/v1 -1 3 0"},
+	}
+	for _, c := range texts {
+		err := c.x.(encoding.TextUnmarshaler).UnmarshalText([]byte(c.text))
+		if !errors.Is(err, ErrInvalidEncoding) {
+			t.Errorf("UnmarshalText(%q) = %v, want ErrInvalidEncoding", c.text, err)
+		}
+	}
+	jsons := []struct {
+		x    any
+		json string
+	}{
+		{new(GF2VectorSpace), `{"version":2,"type":"space","dim":3}`},
+		{new(GF2VectorSpace), `{"version":1,"type":"vector","dim":3}`},
+		{new(GF2VectorSpace), `{"version":1,"type":"space"}`},
+		{new(GF2VectorSpace), `[1]`},
+		{new(GF2Vector), `{"version":1,"type":"vector","dim":3,"value":"11"}`},
+		{new(GF2SubVectorSpace), `{"version":1,"type":"subspace","dim":3}`},
+		{new(BitMatrix), `{"version":1,"type":"matrix","cols":3,"data":["101"]}`},
+		{new(AugmentedBitMatrix), `{"version":1,"type":"matrix","rows":1,"cols":3,"right":4,"data":["101"]}`},
+	}
+	for _, c := range jsons {
+		err := json.Unmarshal([]byte(c.json), c.x)
+		if !errors.Is(err, ErrInvalidEncoding) {
+			t.Errorf("json.Unmarshal(%q) = %v, want ErrInvalidEncoding", c.json, err)
+		}
+	}
+}
+
+func TestEncodingBitMatrixValue(t *testing.T) {
+	bm := bitMatrix(5, 2)
+	want, _ := json.Marshal(&bm)
+	if got, err := json.Marshal(bm); err != nil || string(got) != string(want) {
+		t.Errorf("json.Marshal(BitMatrix) = %s, %v, want %s", got, err, want)
+	}
+	type fields struct {
+		M  BitMatrix
+		AM AugmentedBitMatrix
+	}
+	x := fields{bm, AugmentedBitMatrix{bm, 1}}
+	js, err := json.Marshal(x)
+	if err != nil {
+		t.Fatalf("json.Marshal(%v) error %v", x, err)
+	}
+	var y fields
+	if err := json.Unmarshal(js, &y); err != nil || y.M.Cmp(&bm) != 0 || y.AM.Cmp(&bm) != 0 || y.AM.Right != 1 {
+		t.Errorf("json.Unmarshal(%s) = %v, %v, want %v", js, y, err, x)
+	}
+	if !strings.HasPrefix(string(js), `{"M":{"version":1,`) {
+		t.Errorf("json.Marshal(%v) = %s, want the versioned encoding of M", x, js)
+	}
+}
+
+func TestEncodingSizedBitMatrixZeroCols(t *testing.T) {
+	m := NewBitMatrix(2, 0)
+	if _, err := m.MarshalBinary(); !errors.Is(err, ErrValueOutOfRange) {
+		t.Errorf("MarshalBinary() of 2 x 0 error = %v, want %v", err, ErrValueOutOfRange)
+	}
+	if _, err := m.MarshalText(); !errors.Is(err, ErrValueOutOfRange) {
+		t.Errorf("MarshalText() of 2 x 0 error = %v, want %v", err, ErrValueOutOfRange)
+	}
+	js, err := json.Marshal(m)
+	var y SizedBitMatrix
+	if err != nil || json.Unmarshal(js, &y) != nil || y.Rows() != 2 || y.Cols() != 0 {
+		t.Errorf("json.Unmarshal(%s) = %v x %v, %v", js, y.Rows(), y.Cols(), err)
+	}
+}
@@ -0,0 +1,137 @@
+// Ralf Poeppel, 2026
+//
+// This file implements iterators enumerating sets of vectors of a vector space.
+// The yielded vector is reused for the next step to avoid allocations,
+// use Copy to keep it.
+
+package gf2vs
+
+import (
+	"iter"
+	"math/bits"
+)
+
+// GrayCode return an iterator over all vectors of s in Gray code order,
+// each vector differs in one coordinate from its predecessor.
+// The index of the flipped base vector is yielded with the vector,
+// the first vector is the zero vector with index 0.
+// The iteration of 2**dim vectors is endless in practice for dim >= 64.
+func (s *GF2VectorSpace) GrayCode() iter.Seq2[uint, *GF2Vector] {
+	return func(yield func(uint, *GF2Vector) bool) {
+		v := s.GF2Zeros()
+		if !yield(0, v) {
+			return
+		}
+		for i := uint64(1); ; i++ {
+			k := uint(bits.TrailingZeros64(i)) + 1
+			if k > s.dim {
+				return
+			}
+			if !yield(k, v.FlipBit(k)) {
+				return
+			}
+		}
+	}
+}
+
+// ConstantWeight return an iterator over all vectors of s with exactly k ones
+// in lexicographic order, ascending by value.
+// The next vector is computed by Gosper's hack:
+// https://graphics.stanford.edu/~seander/bithacks.html#NextBitPermutation
+func (s *GF2VectorSpace) ConstantWeight(k uint) iter.Seq[*GF2Vector] {
+	return func(yield func(*GF2Vector) bool) {
+		s.constantWeight(k, s.GF2Zeros(), yield)
+	}
+}
+
+// constantWeight yield the vectors of s with exactly k ones in v,
+// return false if yield returned false.
+func (s *GF2VectorSpace) constantWeight(k uint, v *GF2Vector, yield func(*GF2Vector) bool) bool {
+	if k > s.dim {
+		return true
+	}
+	v.val = 0
+	clear(v.words)
+	for i := uint(1); i <= k; i++ {
+		v.SetBit(i, 1)
+	}
+	if !yield(v) {
+		return false
+	}
+	if k == 0 {
+		return true
+	}
+	if s.isWide() {
+		for nextBitPermutation(v) {
+			if !yield(v) {
+				return false
+			}
+		}
+		return true
+	}
+	for {
+		x := v.val
+		c := x & -x
+		r := x + c
+		if r == 0 {
+			return true // overflow of the most significant bit
+		}
+		x = (((r ^ x) >> 2) / c) | r
+		if x > s.ones {
+			return true
+		}
+		v.val = x
+		if !yield(v) {
+			return false
+		}
+	}
+}
+
+// nextBitPermutation set v to the next larger vector with the same count of
+// ones, return false if there is none. It is Gosper's hack for a slice of
+// words: the lowest run of ones is moved up by one coordinate and all but
+// one of its ones are moved to the least significant coordinates.
+func nextBitPermutation(v *GF2Vector) bool {
+	t, _ := v.Select1(1)
+	u := t
+	for u <= v.sp.dim && v.Bit(u) == 1 {
+		u++
+	}
+	if u > v.sp.dim {
+		return false
+	}
+	v.SetBit(u, 1)
+	for i := t; i < u; i++ {
+		v.SetBit(i, 0)
+	}
+	for i := uint(1); i < u-t; i++ {
+		v.SetBit(i, 1)
+	}
+	return true
+}
+
+// Submasks return an iterator over all vectors z with z & m = z,
+// the 2**OnesCount(m) sub masks of m in descending order from m to zero.
+func (m *GF2Vector) Submasks() iter.Seq[*GF2Vector] {
+	return func(yield func(*GF2Vector) bool) {
+		z := m.Copy()
+		for {
+			if !yield(z) {
+				return
+			}
+			if z.IsZeros() {
+				return
+			}
+			// z = (z - 1) & m
+			if z.words == nil {
+				z.val = (z.val - 1) & m.val
+				continue
+			}
+			borrow := uint(1)
+			for i, w := range z.words {
+				w, borrow = bits.Sub(w, 0, borrow)
+				z.words[i] = w & m.words[i]
+			}
+		}
+	}
+}
@@ -0,0 +1,139 @@
+// Ralf Poeppel, 2026
+
+package gf2vs
+
+import (
+	"fmt"
+	"math/bits"
+	"slices"
+	"testing"
+)
+
+func TestGrayCode(t *testing.T) {
+	cases := []struct {
+		dim  uint
+		want string
+		idx  []uint
+	}{
+		{1, "[0 1]", []uint{0, 1}},
+		{2, "[00 01 11 10]", []uint{0, 1, 2, 1}},
+		{3, "[000 001 011 010 110 111 101 100]", []uint{0, 1, 2, 1, 3, 1, 2, 1}},
+	}
+	for _, c := range cases {
+		var got []string
+		var idx []uint
+		for i, v := range NewGF2VectorSpace(c.dim).GrayCode() {
+			got = append(got, v.String())
+			idx = append(idx, i)
+		}
+		if fmt.Sprint(got) != c.want || !slices.Equal(idx, c.idx) {
+			t.Errorf("%v.GrayCode() = %v, %v, want %v, %v", c.dim, got, idx, c.want, c.idx)
+		}
+	}
+
+	// all vectors, each one differs in the flipped coordinate from its predecessor
+	sp := NewGF2VectorSpace(10)
+	seen := make(map[uint]bool)
+	prev := sp.GF2Zeros()
+	for i, v := range sp.GrayCode() {
+		seen[v.Val()] = true
+		if i > 0 && Xor(prev, v).String() != sp.GF2BaseVector(i).String() {
+			t.Errorf("GrayCode() %v -> %v, flipped %v", prev, v, i)
+		}
+		prev.Set(v)
+	}
+	if len(seen) != 1024 {
+		t.Errorf("10.GrayCode() yield %v vectors, want 1024", len(seen))
+	}
+
+	// wide vector space stopped early
+	n := 0
+	for i, v := range NewGF2VectorSpace(100).GrayCode() {
+		if n == 8 {
+			if i != 4 || v.Val() != 0b1100 {
+				t.Errorf("100.GrayCode() step 8 = %v, %v, want 4, 1100", i, v.Val())
+			}
+			break
+		}
+		n++
+	}
+}
+
+func TestConstantWeight(t *testing.T) {
+	cases := []struct {
+		dim  uint
+		k    uint
+		want string
+	}{
+		{3, 0, "[000]"},
+		{3, 1, "[001 010 100]"},
+		{3, 2, "[011 101 110]"},
+		{3, 3, "[111]"},
+		{3, 4, "[]"},
+		{4, 2, "[0011 0101 0110 1001 1010 1100]"},
+	}
+	for _, c := range cases {
+		var got []string
+		for v := range NewGF2VectorSpace(c.dim).ConstantWeight(c.k) {
+			got = append(got, v.String())
+		}
+		if fmt.Sprint(got) != c.want {
+			t.Errorf("%v.ConstantWeight(%v) = %v, want %v", c.dim, c.k, got, c.want)
+		}
+	}
+
+	// count and order, single word and wide spaces
+	for _, c := range []struct{ dim, k uint }{{10, 3}, {64, 1}, {64, 63}, {64, 2}, {66, 2}, {70, 3}, {130, 1}} {
+		sp := NewGF2VectorSpace(c.dim)
+		var prev *GF2Vector
+		n := uint64(0)
+		for v := range sp.ConstantWeight(c.k) {
+			n++
+			if OnesCount(v) != int(c.k) {
+				t.Fatalf("%v.ConstantWeight(%v) yield %v", c.dim, c.k, v)
+			}
+			if prev != nil && prev.String() >= v.String() {
+				t.Fatalf("%v.ConstantWeight(%v) yield %v after %v", c.dim, c.k, v, prev)
+			}
+			prev = v.Copy()
+		}
+		if want := binomial(c.dim, c.k); n != want {
+			t.Errorf("%v.ConstantWeight(%v) yield %v vectors, want %v", c.dim, c.k, n, want)
+		}
+	}
+}
+
+func TestSubmasks(t *testing.T) {
+	cases := []struct {
+		dim  uint
+		m    []uint
+		want string
+	}{
+		{3, []uint{0}, "[000]"},
+		{3, []uint{0b101}, "[101 100 001 000]"},
+		{4, []uint{0b1011}, "[1011 1010 1001 1000 0011 0010 0001 0000]"},
+	}
+	for _, c := range cases {
+		m := NewGF2VectorSpace(c.dim).NewGF2VectorWords(c.m)
+		var got []string
+		for v := range m.Submasks() {
+			got = append(got, v.String())
+		}
+		if fmt.Sprint(got) != c.want {
+			t.Errorf("%v.Submasks() = %v, want %v", m, got, c.want)
+		}
+	}
+
+	// wide mask across the word boundary
+	m := NewGF2VectorSpace(2*bits.UintSize + 2).NewGF2VectorWords([]uint{1 << (bits.UintSize - 1), 3, 2})
+	seen := make(map[string]bool)
+	for v := range m.Submasks() {
+		if And(v, m).String() != v.String() {
+			t.Errorf("%v is no sub mask of %v", v, m)
+		}
+		seen[v.String()] = true
+	}
+	if len(seen) != 1<<bits.OnesCount(0b1111) {
+		t.Errorf("%v.Submasks() yield %v vectors, want 16", m, len(seen))
+	}
+}
@@ -0,0 +1,172 @@
+// Ralf Poeppel, 2026
+//
+// This file implements the factorization of polynomials over GF(2).
+// The square-free factorization splits a polynomial into the products of the
+// factors of equal multiplicity, with gcd(f, f') and the square root of
+// polynomials with only even exponents.
+// https://en.wikipedia.org/w/index.php?title=Factorization_of_polynomials_over_finite_fields&oldid=1329658423#Square-free_factorization
+// Berlekamp's algorithm factors a square-free polynomial f of degree n:
+// the polynomials g with g^2 = g mod f form the null space of Q - I,
+// row i of Q is x^(2i) mod f. The null space is computed by the row reduced
+// echolon form of the BitMatrix [Q - I | I], its dimension is the count of
+// irreducible factors of f, and the gcds of f with its elements split f.
+// https://en.wikipedia.org/w/index.php?title=Berlekamp%27s_algorithm&oldid=1290394866
+
+package gf2vs
+
+import (
+	"fmt"
+	"math/big"
+	"math/bits"
+	"slices"
+)
+
+// GF2PolyFactor an irreducible factor P of a polynomial with multiplicity E.
+type GF2PolyFactor struct {
+	P *GF2Poly
+	E int
+}
+
+// String return the factor as (P)^E, e.g. "(x^2 + x + 1)^3", the exponent 1 is omitted.
+func (f GF2PolyFactor) String() string {
+	if f.E == 1 {
+		return fmt.Sprintf("(%v)", f.P)
+	}
+	return fmt.Sprintf("(%v)^%v", f.P, f.E)
+}
+
+// Factor return the irreducible factors of p with their multiplicities,
+// ascending by the factors, the product of the factors is p.
+// The factors of 1 are empty.
+// Panic if p is the zero polynomial.
+func Factor(p *GF2Poly) []GF2PolyFactor {
+	if p.IsZero() {
+		panic("Factor(p): p is the zero polynomial")
+	}
+	var fs []GF2PolyFactor
+	for _, sf := range squareFree(p, 1, nil) {
+		for _, q := range berlekamp(sf.P) {
+			fs = append(fs, GF2PolyFactor{q, sf.E})
+		}
+	}
+	slices.SortFunc(fs, func(a, b GF2PolyFactor) int { return a.P.Cmp(b.P) })
+	return fs
+}
+
+// unspread return the bits of the even positions of x in the lower half,
+// the inverse of spread.
+func unspread(x uint64) uint64 {
+	x &= 0x5555555555555555
+	x = (x | x>>1) & 0x3333333333333333
+	x = (x | x>>2) & 0x0f0f0f0f0f0f0f0f
+	x = (x | x>>4) & 0x00ff00ff00ff00ff
+	x = (x | x>>8) & 0x0000ffff0000ffff
+	x = (x | x>>16) & 0x00000000ffffffff
+	return x
+}
+
+// sqrt sets z to the square root of x and returns z,
+// x must have only even exponents, the inverse of Sqr.
+func (z *GF2Poly) sqrt(x *GF2Poly) *GF2Poly {
+	const half = bits.UintSize / 2
+	w := make([]uint, (len(x.w)+1)/2)
+	for i, a := range x.w {
+		w[i/2] |= uint(unspread(uint64(a))) << (half * (i % 2))
+	}
+	z.w = w
+	return z.norm()
+}
+
+// squareFree append the square-free factors of p with their multiplicities
+// times e to fs and return fs. The factors are pairwise coprime.
+func squareFree(p *GF2Poly, e int, fs []GF2PolyFactor) []GF2PolyFactor {
+	c := new(GF2Poly).GCD(nil, nil, p, new(GF2Poly).Derivative(p))
+	w := new(GF2Poly).Div(p, c)
+	y := new(GF2Poly)
+	// w is the product of the factors of multiplicity at least i not divisible by 2
+	for i := 1; !w.IsOne(); i++ {
+		y.GCD(nil, nil, w, c)
+		if q := new(GF2Poly).Div(w, y); !q.IsOne() {
+			fs = append(fs, GF2PolyFactor{q, i * e})
+		}
+		w, y = y, w
+		c.Div(c, w)
+	}
+	if !c.IsOne() {
+		// c' = 0, c is a square
+		fs = squareFree(c.sqrt(c), 2*e, fs)
+	}
+	return fs
+}
+
+// polyInt return the big.Int with the coefficients of p as bits.
+func polyInt(p *GF2Poly) *big.Int {
+	w := make([]big.Word, len(p.w))
+	for i, a := range p.w {
+		w[i] = big.Word(a)
+	}
+	return new(big.Int).SetBits(w)
+}
+
+// intPoly return the polynomial with the bits of x as coefficients.
+func intPoly(x *big.Int) *GF2Poly {
+	w := make([]uint, len(x.Bits()))
+	for i, a := range x.Bits() {
+		w[i] = uint(a)
+	}
+	return NewGF2PolyWords(w)
+}
+
+// berlekampBasis return a basis of the polynomials g with g^2 = g mod f,
+// the null space of Q - I of the square-free polynomial f.
+func berlekampBasis(f *GF2Poly) []*GF2Poly {
+	n := f.Degree()
+	bm := make(BitMatrix, n)
+	x2 := new(GF2Poly).Mod(NewGF2Poly(2), f)
+	h := NewGF2Poly(0)
+	for i := range n {
+		// row i of [Q - I | I]
+		r := polyInt(new(GF2Poly).Add(h, NewGF2Poly(uint(i))))
+		r.Lsh(r, uint(n)).SetBit(r, i, 1)
+		bm[i] = r
+		h.MulMod(h, x2, f)
+	}
+	bm.RowReducedEcholonForm(n)
+	// the rows with zero left side are the basis
+	var basis []*GF2Poly
+	for _, r := range bm {
+		if r.BitLen() <= n {
+			basis = append(basis, intPoly(r))
+		}
+	}
+	return basis
+}
+
+// berlekamp return the irreducible factors of the square-free polynomial f.
+func berlekamp(f *GF2Poly) []*GF2Poly {
+	if f.Degree() <= 1 {
+		return []*GF2Poly{f}
+	}
+	basis := berlekampBasis(f)
+	fs := []*GF2Poly{f}
+	d := new(GF2Poly)
+	for _, g := range basis {
+		if len(fs) == len(basis) {
+			break
+		}
+		if g.Degree() < 1 {
+			continue
+		}
+		var next []*GF2Poly
+		for _, u := range fs {
+			d.GCD(nil, nil, u, g)
+			if d.Degree() < 1 || d.Degree() == u.Degree() {
+				next = append(next, u)
+				continue
+			}
+			next = append(next, new(GF2Poly).Set(d), new(GF2Poly).Div(u, d))
+		}
+		fs = next
+	}
+	return fs
+}
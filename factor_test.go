@@ -0,0 +1,94 @@
+// Ralf Poeppel, 2026
+
+package gf2vs
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"testing"
+)
+
+func TestDerivative(t *testing.T) {
+	cases := []struct {
+		p    *GF2Poly
+		want string
+	}{
+		{new(GF2Poly), "0"},
+		{NewGF2Poly(0), "0"},
+		{NewGF2Poly(1), "1"},
+		{NewGF2Poly(4, 3, 1, 0), "x^2 + 1"},
+		{NewGF2Poly(65, 64, 63), "x^64 + x^62"},
+		{NewGF2Poly(128, 2), "0"},
+	}
+	for _, c := range cases {
+		if got := new(GF2Poly).Derivative(c.p); got.String() != c.want {
+			t.Errorf("Derivative(%v) = %v, want %v", c.p, got, c.want)
+		}
+	}
+}
+
+func TestSqrt(t *testing.T) {
+	r := rand.New(rand.NewPCG(1, 2))
+	for n := range 5 {
+		p := randomPoly(r, n)
+		if got := new(GF2Poly).sqrt(new(GF2Poly).Sqr(p)); !got.Equal(p) {
+			t.Errorf("sqrt(Sqr(%v)) = %v", p, got)
+		}
+	}
+}
+
+func TestFactor(t *testing.T) {
+	x, x1, x2 := NewGF2Poly(1), NewGF2Poly(1, 0), NewGF2Poly(2, 1, 0)
+	cases := []struct {
+		p    *GF2Poly
+		want string
+	}{
+		{NewGF2Poly(0), "[]"},
+		{x, "[(x)]"},
+		{NewGF2Poly(8, 4, 3, 1, 0), "[(x^8 + x^4 + x^3 + x + 1)]"},
+		{NewGF2Poly(2, 0), "[(x + 1)^2]"},
+		{NewGF2Poly(4, 1), "[(x) (x + 1) (x^2 + x + 1)]"},
+		{NewGF2Poly(8, 1), "[(x) (x + 1) (x^3 + x + 1) (x^3 + x^2 + 1)]"},
+		{NewGF2Poly(8, 1, 0), "[(x^2 + x + 1) (x^6 + x^5 + x^3 + x^2 + 1)]"},
+		{new(GF2Poly).Mul(new(GF2Poly).Mul(NewGF2Poly(12), x1), new(GF2Poly).Mul(x2, new(GF2Poly).Mul(x2, x2))),
+			"[(x)^12 (x + 1) (x^2 + x + 1)^3]"},
+		{new(GF2Poly).Mul(NewGF2Poly(127, 1, 0), NewGF2Poly(127, 1, 0)), "[(x^127 + x + 1)^2]"},
+	}
+	for _, c := range cases {
+		if got := fmt.Sprint(Factor(c.p)); got != c.want {
+			t.Errorf("Factor(%v) = %v, want %v", c.p, got, c.want)
+		}
+	}
+}
+
+func TestFactorRandom(t *testing.T) {
+	r := rand.New(rand.NewPCG(1, 2))
+	for range 50 {
+		p := randomPoly(r, 1+r.IntN(2))
+		p.Mul(p, randomPoly(r, 1))
+		if p.IsZero() {
+			continue
+		}
+		prd := NewGF2Poly(0)
+		for i, f := range Factor(p) {
+			if !f.P.IsIrreducible() || f.E < 1 {
+				t.Errorf("Factor(%v)[%v] = %v", p, i, f)
+			}
+			for range f.E {
+				prd.Mul(prd, f.P)
+			}
+		}
+		if !prd.Equal(p) {
+			t.Errorf("product of Factor(%v) = %v", p, prd)
+		}
+	}
+}
+
+func TestFactorZero(t *testing.T) {
+	defer func() {
+		if got := recover(); got != "Factor(p): p is the zero polynomial" {
+			t.Errorf("Factor(0) panic %v", got)
+		}
+	}()
+	Factor(new(GF2Poly))
+}
@@ -0,0 +1,286 @@
+// Ralf Poeppel, 2026
+//
+// This file implements the extension fields GF(2^n) = GF(2)[x]/(m) of an
+// irreducible polynomial m of degree n. The additive group of the field is
+// the vector space of dimension n, an element is a vector, the coordinate i
+// is the coefficient of x^(i-1) of the polynomial representing it.
+// For n <= 16 the multiplication uses log and antilog tables of a generator
+// of the multiplicative group, for larger n the carry-less multiplication of
+// the polynomials and the reduction modulo m.
+
+package gf2vs
+
+import (
+	"fmt"
+	"math/big"
+	"sync"
+)
+
+// maxTableDim the largest dimension of fields with log and antilog tables.
+const maxTableDim = 16
+
+// GF2Field represents the field GF(2^n) of an irreducible polynomial of degree n.
+type GF2Field struct {
+	sp   *GF2VectorSpace // additive group
+	mod  *GF2Poly        // irreducible polynomial of degree n
+	gen  *GF2Vector      // generator of the multiplicative group
+	once sync.Once       // searches gen of a field without tables once
+	log  []uint32        // log[a] = k with gen^k = a for a != 0, n <= maxTableDim
+	exp  []uint32        // exp[k] = gen^k for k in [0, 2*(2^n-1)), n <= maxTableDim
+}
+
+// NewGF2Field create the field of the irreducible polynomial m.
+// Panic if m is not irreducible, it is NewGF2FieldErr panicking with its error.
+func NewGF2Field(m *GF2Poly) *GF2Field {
+	f, err := NewGF2FieldErr(m)
+	if err != nil {
+		panic(err.Error())
+	}
+	return f
+}
+
+// NewGF2FieldErr create the field of the irreducible polynomial m.
+// Return an error wrapping ErrValueOutOfRange if m is not irreducible.
+func NewGF2FieldErr(m *GF2Poly) (*GF2Field, error) {
+	if !m.IsIrreducible() {
+		return nil, outOfRange("NewGF2Field(m)", "%v is not irreducible", m)
+	}
+	f := &GF2Field{sp: NewGF2VectorSpace(uint(m.Degree())), mod: new(GF2Poly).Set(m)}
+	if f.sp.dim <= maxTableDim {
+		f.tables()
+	}
+	return f, nil
+}
+
+// mulWord return a * b mod m for single word elements of f without tables.
+func (f *GF2Field) mulWord(a, b uint) uint {
+	p := NewGF2PolyWords([]uint{a})
+	p.MulMod(p, NewGF2PolyWords([]uint{b}), f.mod)
+	if p.IsZero() {
+		return 0
+	}
+	return p.w[0]
+}
+
+// tables search a generator of the multiplicative group and compute the
+// log and antilog tables. The generator is x, if m is primitive.
+func (f *GF2Field) tables() {
+	order := uint32(f.sp.ones)
+	f.exp = make([]uint32, 2*order)
+	f.log = make([]uint32, order+1)
+	for g := uint(2); ; g++ {
+		if f.sp.dim == 1 {
+			g = 1
+		}
+		e := uint(1)
+		k := uint32(0)
+		for ; k < order; k++ {
+			if e == 1 && k > 0 {
+				break // order of g is k
+			}
+			f.exp[k] = uint32(e)
+			e = f.mulWord(e, g)
+		}
+		if k == order {
+			f.gen = f.sp.NewGF2Vector(g)
+			break
+		}
+	}
+	for k := range order {
+		f.exp[k+order] = f.exp[k]
+		f.log[f.exp[k]] = k
+	}
+}
+
+// String return the field as GF(2^n)[m].
+func (f *GF2Field) String() string {
+	return fmt.Sprintf("GF(2^%v)[%v]", f.sp.dim, f.mod)
+}
+
+// Space return the vector space of the elements of f.
+func (f *GF2Field) Space() *GF2VectorSpace {
+	return f.sp
+}
+
+// Modulus return a copy of the irreducible polynomial of f.
+func (f *GF2Field) Modulus() *GF2Poly {
+	return new(GF2Poly).Set(f.mod)
+}
+
+// Element return the element of f represented by p mod m.
+func (f *GF2Field) Element(p *GF2Poly) *GF2Vector {
+	r := new(GF2Poly).Mod(p, f.mod)
+	z := f.sp.GF2Zeros()
+	if z.words == nil {
+		if !r.IsZero() {
+			z.val = r.w[0]
+		}
+		return z
+	}
+	copy(z.words, r.w)
+	return z
+}
+
+// Poly return the polynomial representing a.
+// Panic if a is not of the vector space of f.
+func (f *GF2Field) Poly(a *GF2Vector) *GF2Poly {
+	f.check("Poly", a)
+	return NewGF2PolyWords(wordsOf(a))
+}
+
+// check panic if a is not of the vector space of f.
+func (f *GF2Field) check(op string, a *GF2Vector) {
+	if a.sp.dim != f.sp.dim {
+		panic(mismatch(op, f.sp.dim, a.sp.dim).Error())
+	}
+}
+
+// Generator return a generator of the multiplicative group of f,
+// x if the polynomial of f is primitive.
+// The search of a generator of a field without tables needs the primes
+// of 2^n - 1, which is expensive for large n, it is done once on the first
+// call. Generator is safe for concurrent use.
+func (f *GF2Field) Generator() *GF2Vector {
+	f.once.Do(func() {
+		if f.gen == nil {
+			f.gen = f.searchGenerator()
+		}
+	})
+	return f.gen.Copy()
+}
+
+// searchGenerator return the smallest generator of the multiplicative group
+// of a field without tables, g is a generator, if g^((2^n - 1)/q) != 1 for
+// each prime q of 2^n - 1.
+func (f *GF2Field) searchGenerator() *GF2Vector {
+	m := mersenne(int(f.sp.dim))
+	fs := primeFactors(m)
+	e := new(big.Int)
+search:
+	for g := uint(2); ; g++ {
+		v := f.sp.NewGF2Vector(g)
+		for _, q := range fs {
+			if f.Poly(f.Pow(v, e.Quo(m, q))).IsOne() {
+				continue search
+			}
+		}
+		return v
+	}
+}
+
+// Add return a + b, the sum is the difference a - b.
+// Panic if a or b is not of the vector space of f.
+func (f *GF2Field) Add(a, b *GF2Vector) *GF2Vector {
+	f.check("Add", a)
+	f.check("Add", b)
+	return Xor(a, b)
+}
+
+// Mul return the product a * b.
+// Panic if a or b is not of the vector space of f.
+func (f *GF2Field) Mul(a, b *GF2Vector) *GF2Vector {
+	f.check("Mul", a)
+	f.check("Mul", b)
+	if f.log != nil {
+		if a.val == 0 || b.val == 0 {
+			return f.sp.GF2Zeros()
+		}
+		return f.sp.NewGF2Vector(uint(f.exp[f.log[a.val]+f.log[b.val]]))
+	}
+	p := f.Poly(a)
+	return f.Element(p.Mul(p, f.Poly(b)))
+}
+
+// Inv return the multiplicative inverse of a.
+// Panic if a is zero or not of the vector space of f.
+func (f *GF2Field) Inv(a *GF2Vector) *GF2Vector {
+	f.check("Inv", a)
+	if a.IsZeros() {
+		panic("Inv(a): a is zero")
+	}
+	if f.log != nil {
+		order := uint32(f.sp.ones)
+		return f.sp.NewGF2Vector(uint(f.exp[order-f.log[a.val]]))
+	}
+	s := new(GF2Poly)
+	new(GF2Poly).GCD(s, nil, f.Poly(a), f.mod)
+	return f.Element(s)
+}
+
+// Div return the quotient a / b.
+// Panic if b is zero or a or b is not of the vector space of f.
+func (f *GF2Field) Div(a, b *GF2Vector) *GF2Vector {
+	return f.Mul(a, f.Inv(b))
+}
+
+// Pow return a**e, 0**0 is 1.
+// Panic if a is not of the vector space of f or e is negative.
+func (f *GF2Field) Pow(a *GF2Vector, e *big.Int) *GF2Vector {
+	f.check("Pow", a)
+	if e.Sign() < 0 {
+		panic(fmt.Sprintf("Pow(a, e): e = %v is negative", e))
+	}
+	if f.log != nil {
+		if e.Sign() == 0 {
+			return f.sp.NewGF2Vector(1)
+		}
+		if a.val == 0 {
+			return f.sp.GF2Zeros()
+		}
+		order := big.NewInt(int64(f.sp.ones))
+		k := new(big.Int).Mul(e, big.NewInt(int64(f.log[a.val])))
+		return f.sp.NewGF2Vector(uint(f.exp[k.Mod(k, order).Uint64()]))
+	}
+	return f.Element(new(GF2Poly).ModExp(f.Poly(a), e, f.mod))
+}
+
+// frobenius return a**(2**k) by k squarings.
+func (f *GF2Field) frobenius(a *GF2Vector, k uint) *GF2Vector {
+	if f.log != nil {
+		z := a.Copy()
+		for range k {
+			if z.val != 0 {
+				z.val = uint(f.exp[2*f.log[z.val]])
+			}
+		}
+		return z
+	}
+	p := f.Poly(a)
+	for range k {
+		p.Mod(p.Sqr(p), f.mod)
+	}
+	return f.Element(p)
+}
+
+// Sqrt return the square root of a, each element of GF(2^n) has exactly one,
+// it is a**(2**(n-1)).
+// Panic if a is not of the vector space of f.
+func (f *GF2Field) Sqrt(a *GF2Vector) *GF2Vector {
+	f.check("Sqrt", a)
+	return f.frobenius(a, f.sp.dim-1)
+}
+
+// Trace return the absolute trace of a, the sum of the conjugates
+// a + a**2 + a**4 + ... + a**(2**(n-1)), it is 0 or 1.
+// Panic if a is not of the vector space of f.
+func (f *GF2Field) Trace(a *GF2Vector) uint {
+	f.check("Trace", a)
+	t, c := a.Copy(), a
+	for range f.sp.dim - 1 {
+		c = f.frobenius(c, 1)
+		t.Xor(t, c)
+	}
+	return t.Bit(1)
+}
+
+// Norm return the absolute norm of a, the product of the conjugates
+// a * a**2 * a**4 * ... * a**(2**(n-1)) = a**(2**n - 1),
+// it is 1 for all elements but zero.
+// Panic if a is not of the vector space of f.
+func (f *GF2Field) Norm(a *GF2Vector) uint {
+	f.check("Norm", a)
+	if a.IsZeros() {
+		return 0
+	}
+	return 1
+}
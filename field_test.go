@@ -0,0 +1,190 @@
+// Ralf Poeppel, 2026
+
+package gf2vs
+
+import (
+	"errors"
+	"math/big"
+	"math/rand/v2"
+	"sync"
+	"testing"
+)
+
+func TestNewGF2FieldErr(t *testing.T) {
+	for _, m := range []*GF2Poly{new(GF2Poly), NewGF2Poly(0), NewGF2Poly(8, 1, 0), NewGF2Poly(2, 0)} {
+		if _, err := NewGF2FieldErr(m); !errors.Is(err, ErrValueOutOfRange) {
+			t.Errorf("NewGF2FieldErr(%v) error = %v, want %v", m, err, ErrValueOutOfRange)
+		}
+	}
+	f := NewGF2Field(NewGF2Poly(8, 4, 3, 1, 0))
+	if got, want := f.String(), "GF(2^8)[x^8 + x^4 + x^3 + x + 1]"; got != want {
+		t.Errorf("String() = %v, want %v", got, want)
+	}
+	if f.Space().dim != 8 || !f.Modulus().Equal(NewGF2Poly(8, 4, 3, 1, 0)) {
+		t.Errorf("Space() = %v, Modulus() = %v", f.Space(), f.Modulus())
+	}
+}
+
+func TestGF2FieldAES(t *testing.T) {
+	// FIPS-197 examples, the AES polynomial is not primitive, x + 1 generates
+	f := NewGF2Field(NewGF2Poly(8, 4, 3, 1, 0))
+	sp := f.Space()
+	cases := []struct{ a, b, want uint }{
+		{0x57, 0x83, 0xc1},
+		{0x57, 0x13, 0xfe},
+		{0x53, 0xca, 0x01},
+		{0x00, 0xca, 0x00},
+	}
+	for _, c := range cases {
+		if got := f.Mul(sp.NewGF2Vector(c.a), sp.NewGF2Vector(c.b)); got.Val() != c.want {
+			t.Errorf("Mul(%#x, %#x) = %#x, want %#x", c.a, c.b, got.Val(), c.want)
+		}
+	}
+	if got := f.Inv(sp.NewGF2Vector(0x53)); got.Val() != 0xca {
+		t.Errorf("Inv(0x53) = %#x, want 0xca", got.Val())
+	}
+	if got := f.Generator(); got.Val() != 3 {
+		t.Errorf("Generator() = %v, want 3", got.Val())
+	}
+}
+
+// testFields the fields of the tests, with and without tables.
+var testFields = []*GF2Poly{
+	NewGF2Poly(1),
+	NewGF2Poly(2, 1, 0),
+	NewGF2Poly(4, 3, 2, 1, 0),
+	NewGF2Poly(8, 4, 3, 1, 0),
+	NewGF2Poly(16, 5, 3, 1, 0),
+	NewGF2Poly(17, 3, 0),
+	NewGF2Poly(64, 4, 3, 1, 0),
+	NewGF2Poly(128, 7, 2, 1, 0),
+	NewGF2Poly(131, 8, 3, 2, 0),
+}
+
+func TestGF2FieldArithmetic(t *testing.T) {
+	r := rand.New(rand.NewPCG(1, 2))
+	for _, m := range testFields {
+		f := NewGF2Field(m)
+		sp := f.Space()
+		one := sp.NewGF2Vector(1)
+		order := mersenne(m.Degree())
+		for range 20 {
+			a, b, c := randomVector(r, sp, 32), randomVector(r, sp, 32), randomVector(r, sp, 32)
+			ab := f.Mul(a, b)
+			if want := new(GF2Poly).MulMod(f.Poly(a), f.Poly(b), m); !f.Poly(ab).Equal(want) {
+				t.Errorf("%v: Mul(%v, %v) = %v, want %v", f, a, b, ab, want)
+			}
+			if got, want := f.Mul(a, f.Add(b, c)), f.Add(ab, f.Mul(a, c)); got.String() != want.String() {
+				t.Errorf("%v: Mul(%v, Add(%v, %v)) = %v, want %v", f, a, b, c, got, want)
+			}
+			if got := f.Pow(a, order); a.IsZeros() != got.IsZeros() || !a.IsZeros() && got.String() != one.String() {
+				t.Errorf("%v: Pow(%v, 2^n - 1) = %v", f, a, got)
+			}
+			if got := f.Pow(a, big.NewInt(3)); got.String() != f.Mul(a, f.Mul(a, a)).String() {
+				t.Errorf("%v: Pow(%v, 3) = %v", f, a, got)
+			}
+			if got := f.Sqrt(a); f.Mul(got, got).String() != a.String() {
+				t.Errorf("%v: Sqrt(%v) = %v", f, a, got)
+			}
+			if got, want := f.Trace(f.Add(a, b)), f.Trace(a)^f.Trace(b); got != want {
+				t.Errorf("%v: Trace(%v + %v) = %v, want %v", f, a, b, got, want)
+			}
+			if a.IsZeros() {
+				continue
+			}
+			if got := f.Mul(a, f.Inv(a)); got.String() != one.String() {
+				t.Errorf("%v: Mul(%v, Inv(%v)) = %v", f, a, a, got)
+			}
+			if got := f.Mul(f.Div(b, a), a); got.String() != b.String() {
+				t.Errorf("%v: Div(%v, %v) * %v = %v", f, b, a, a, got)
+			}
+			if f.Norm(a) != 1 {
+				t.Errorf("%v: Norm(%v) = %v, want 1", f, a, f.Norm(a))
+			}
+		}
+		if got := f.Pow(sp.GF2Zeros(), new(big.Int)); got.String() != one.String() {
+			t.Errorf("%v: Pow(0, 0) = %v, want 1", f, got)
+		}
+		if f.Norm(sp.GF2Zeros()) != 0 || f.Trace(sp.GF2Zeros()) != 0 {
+			t.Errorf("%v: Norm(0) = %v, Trace(0) = %v", f, f.Norm(sp.GF2Zeros()), f.Trace(sp.GF2Zeros()))
+		}
+	}
+}
+
+func TestGF2FieldTrace(t *testing.T) {
+	// half of the elements have trace 1
+	for _, m := range testFields[:5] {
+		f := NewGF2Field(m)
+		count := uint(0)
+		for a := range f.Space().ones + 1 {
+			count += f.Trace(f.Space().NewGF2Vector(a))
+		}
+		if want := uint(1) << (m.Degree() - 1); count != want {
+			t.Errorf("%v: %v elements of trace 1, want %v", f, count, want)
+		}
+	}
+}
+
+func TestGF2FieldGenerator(t *testing.T) {
+	for _, m := range testFields {
+		f := NewGF2Field(m)
+		g := f.Generator()
+		if m.IsPrimitive() && g.String() != f.Element(NewGF2Poly(1)).String() {
+			t.Errorf("%v: Generator() = %v, want x", f, f.Poly(g))
+		}
+		order := mersenne(m.Degree())
+		for _, q := range primeFactors(order) {
+			e := new(big.Int).Quo(order, q)
+			if f.Poly(f.Pow(g, e)).IsOne() {
+				t.Errorf("%v: Generator() = %v has order dividing %v", f, g, e)
+			}
+		}
+	}
+}
+
+func TestGF2FieldGeneratorConcurrent(t *testing.T) {
+	// a field without tables searches its generator on the first call
+	f := NewGF2Field(NewGF2Poly(31, 3, 0))
+	gs := make([]*GF2Vector, 4)
+	var wg sync.WaitGroup
+	for i := range gs {
+		wg.Go(func() { gs[i] = f.Generator() })
+	}
+	wg.Wait()
+	for _, g := range gs {
+		if g.String() != gs[0].String() {
+			t.Errorf("Generator() = %v and %v", g, gs[0])
+		}
+	}
+}
+
+func TestGF2FieldElement(t *testing.T) {
+	f := NewGF2Field(NewGF2Poly(8, 4, 3, 1, 0))
+	if got := f.Element(NewGF2Poly(8)); got.Val() != 0x1b {
+		t.Errorf("Element(x^8) = %#x, want 0x1b", got.Val())
+	}
+	g := NewGF2Field(NewGF2Poly(128, 7, 2, 1, 0))
+	p := NewGF2Poly(127, 64, 3)
+	if got := g.Poly(g.Element(p)); !got.Equal(p) {
+		t.Errorf("Poly(Element(%v)) = %v", p, got)
+	}
+	if got := g.Poly(g.Element(NewGF2Poly(128))); !got.Equal(NewGF2Poly(7, 2, 1, 0)) {
+		t.Errorf("Element(x^128) = %v, want x^7 + x^2 + x + 1", got)
+	}
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Mul of vectors of dimension 8 and 9 does not panic")
+		}
+	}()
+	f.Mul(f.Space().GF2Zeros(), NewGF2VectorSpace(9).GF2Zeros())
+}
+
+func TestGF2FieldInvZero(t *testing.T) {
+	f := NewGF2Field(NewGF2Poly(4, 1, 0))
+	defer func() {
+		if got := recover(); got != "Inv(a): a is zero" {
+			t.Errorf("Inv(0) panic %v", got)
+		}
+	}()
+	f.Inv(f.Space().GF2Zeros())
+}
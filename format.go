@@ -0,0 +1,206 @@
+// Ralf Poeppel, 2026
+//
+// This file implements fmt.Formatter for vectors and bit matrices,
+// and the parsers of their string representations.
+
+package gf2vs
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// directive return the format directive of f for verb with precision prec,
+// the flags and the width of f are kept.
+func directive(f fmt.State, verb rune, prec int) string {
+	var sb strings.Builder
+	sb.WriteByte('%')
+	for _, c := range "+-# 0" {
+		if f.Flag(int(c)) {
+			sb.WriteRune(c)
+		}
+	}
+	if w, ok := f.Width(); ok {
+		sb.WriteString(strconv.Itoa(w))
+	}
+	if prec >= 0 {
+		sb.WriteByte('.')
+		sb.WriteString(strconv.Itoa(prec))
+	}
+	sb.WriteRune(verb)
+	return sb.String()
+}
+
+// zeroPad return -1 instead of prec, if the flag '0' pads to a width of
+// at least prec digits, a precision disables the flag '0'.
+func zeroPad(f fmt.State, prec int) int {
+	if w, ok := f.Width(); ok && f.Flag('0') && w >= prec {
+		return -1
+	}
+	return prec
+}
+
+// bases the bases of the verbs of integers.
+var bases = map[rune]int{'b': 2, 'o': 8, 'O': 8, 'd': 10, 'x': 16, 'X': 16}
+
+// digits return the count of digits of n bits in the base of verb,
+// for %d it is -1, no padding.
+func digits(verb rune, n int) int {
+	switch verb {
+	case 'b':
+		return n
+	case 'o', 'O':
+		return (n + 2) / 3
+	case 'x', 'X':
+		return (n + 3) / 4
+	}
+	return -1
+}
+
+// Format implements fmt.Formatter.
+//   - %v, %+v and %s write String, the binary value padded to dim digits.
+//   - %b, %o, %O, %x, %X write the value padded to the digits of dim bits,
+//     %d writes the value in decimal.
+//
+// Width, precision and the flags are used as for big.Int,
+// '#' adds the prefix of the base.
+func (v *GF2Vector) Format(f fmt.State, verb rune) {
+	switch verb {
+	case 'v', 's':
+		fmt.Fprintf(f, fmt.FormatString(f, 's'), v.String())
+	case 'b', 'o', 'O', 'd', 'x', 'X':
+		prec, ok := f.Precision()
+		if !ok {
+			prec = zeroPad(f, digits(verb, int(v.sp.dim)))
+		}
+		fmt.Fprintf(f, directive(f, verb, prec), intOf(v))
+	default:
+		fmt.Fprintf(f, "%%!%c(*gf2vs.GF2Vector=%s)", verb, v.String())
+	}
+}
+
+// Format implements fmt.Formatter, the rows are written one per line.
+//   - %v and %s write String.
+//   - %+v writes the rows in binary with '|' before the precision rightmost
+//     columns, the augmented matrix of XorSatSolve for mr = precision.
+//     Without precision there is no '|'.
+//   - %b, %o, %O, %d, %x, %X write the rows padded to the same count of digits.
+//
+// Width, precision and the flags are used for each row as for big.Int,
+// '#' adds the prefix of the base.
+func (bm *BitMatrix) Format(f fmt.State, verb rune) {
+	switch verb {
+	case 'v', 's':
+		if verb == 'v' && f.Flag('+') {
+			right, _ := f.Precision()
+			bm.formatAugmented(f, bm.cols(right), right)
+			return
+		}
+		fmt.Fprintf(f, fmt.FormatString(f, 's'), bm.String())
+	case 'b', 'o', 'O', 'd', 'x', 'X':
+		prec, ok := f.Precision()
+		for _, row := range *bm {
+			prec = max(prec, len(row.Text(bases[verb])))
+		}
+		if !ok {
+			prec = zeroPad(f, prec)
+		}
+		d := directive(f, verb, prec)
+		for _, row := range *bm {
+			fmt.Fprintf(f, d+"\n", row)
+		}
+	default:
+		fmt.Fprintf(f, "%%!%c(*gf2vs.BitMatrix=%s)", verb, bm.String())
+	}
+}
+
+// formatAugmented write the rows of bm in binary of cols digits with '|'
+// before the right rightmost columns, without '|' if right is 0.
+func (bm *BitMatrix) formatAugmented(f fmt.State, cols, right int) {
+	for _, row := range *bm {
+		s := binaryText(row, cols)
+		if right == 0 {
+			fmt.Fprintf(f, "%s\n", s)
+			continue
+		}
+		fmt.Fprintf(f, "%s|%s\n", s[:cols-right], s[cols-right:])
+	}
+}
+
+// Format implements fmt.Formatter as BitMatrix.Format,
+// %+v writes '|' before the Right rightmost columns.
+func (am *AugmentedBitMatrix) Format(f fmt.State, verb rune) {
+	if verb == 'v' && f.Flag('+') {
+		am.formatAugmented(f, am.cols(am.Right), am.Right)
+		return
+	}
+	am.BitMatrix.Format(f, verb)
+}
+
+// Format implements fmt.Formatter as BitMatrix.Format, but
+// %v and %s write String, the rows in binary of Cols digits, and
+// %+v writes the rows in binary of Cols digits with '|' before the
+// precision rightmost columns.
+func (m *SizedBitMatrix) Format(f fmt.State, verb rune) {
+	switch {
+	case verb == 'v' && f.Flag('+'):
+		right, _ := f.Precision()
+		m.rows.formatAugmented(f, max(m.cols, right), right)
+	case verb == 'v' || verb == 's':
+		fmt.Fprintf(f, fmt.FormatString(f, 's'), m.String())
+	default:
+		m.rows.Format(f, verb)
+	}
+}
+
+// ParseGF2Vector return the vector of space with the value given by s.
+// s is a binary string as written by String and %b, or a number with the
+// prefix 0b, 0o or 0x as written by %#b, %O and %#x.
+// Return an error wrapping ErrInvalidEncoding if s is no number, or
+// wrapping ErrValueOutOfRange if the value has more than space.dim bits.
+func ParseGF2Vector(space *GF2VectorSpace, s string) (*GF2Vector, error) {
+	const op = "ParseGF2Vector(space, s)"
+	base := 2
+	if len(s) > 2 && s[0] == '0' && strings.ContainsRune("bBoOxX", rune(s[1])) {
+		base = 0
+	}
+	x, ok := new(big.Int).SetString(s, base)
+	if !ok || x.Sign() < 0 || strings.HasPrefix(s, "+") {
+		return nil, invalid(op, "%q is no number", s)
+	}
+	if uint(x.BitLen()) > space.dim {
+		return nil, outOfRange(op, "%q has more than %v bits", s, space.dim)
+	}
+	return space.vectorOf(x), nil
+}
+
+// ParseBitMatrix return the matrix of the string s as written by
+// Text(base, sep): the rows in base separated and terminated by sep.
+// A missing terminating sep is accepted.
+// Return an error wrapping ErrValueOutOfRange if base is not in
+// [2, big.MaxBase] or sep is empty, and wrapping ErrInvalidEncoding
+// if a row is no number of base.
+func ParseBitMatrix(s string, base int, sep string) (BitMatrix, error) {
+	const op = "ParseBitMatrix(s, base, sep)"
+	if base < 2 || base > big.MaxBase {
+		return nil, outOfRange(op, "base = %v out of range [2, %v]", base, big.MaxBase)
+	}
+	if sep == "" {
+		return nil, outOfRange(op, "sep is empty")
+	}
+	rows := strings.Split(s, sep)
+	if rows[len(rows)-1] == "" {
+		rows = rows[:len(rows)-1]
+	}
+	bm := make(BitMatrix, len(rows))
+	for i, r := range rows {
+		x, ok := new(big.Int).SetString(r, base)
+		if !ok || x.Sign() < 0 || strings.HasPrefix(r, "+") {
+			return nil, invalid(op, "row %v = %q is no number of base %v", i, r, base)
+		}
+		bm[i] = x
+	}
+	return bm, nil
+}
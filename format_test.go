@@ -0,0 +1,153 @@
+// Ralf Poeppel, 2026
+
+package gf2vs
+
+import (
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"testing"
+)
+
+func TestFormatGF2Vector(t *testing.T) {
+	v := NewGF2VectorSpace(10).NewGF2Vector(0x2d)
+	w, _ := ParseGF2Vector(NewGF2VectorSpace(68), "0xa00000000000000ff")
+	cases := []struct {
+		format string
+		v      *GF2Vector
+		want   string
+	}{
+		{"%v", v, "0000101101"},
+		{"%+v", v, "0000101101"},
+		{"%s", v, "0000101101"},
+		{"%12v", v, "  0000101101"},
+		{"%-12v|", v, "0000101101  |"},
+		{"%b", v, "0000101101"},
+		{"%#b", v, "0b0000101101"},
+		{"%.3b", v, "101101"},
+		{"%o", v, "0055"},
+		{"%O", v, "0o0055"},
+		{"%x", v, "02d"},
+		{"%#x", v, "0x02d"},
+		{"%X", v, "02D"},
+		{"%6x", v, "   02d"},
+		{"%06x", v, "00002d"},
+		{"%d", v, "45"},
+		{"%5d", v, "   45"},
+		{"%x", w, "a00000000000000ff"},
+		{"%d", w, "184467440737095516415"},
+		{"%q", v, "%!q(*gf2vs.GF2Vector=0000101101)"},
+	}
+	for _, c := range cases {
+		if got := fmt.Sprintf(c.format, c.v); got != c.want {
+			t.Errorf("Sprintf(%q, %v) = %q, want %q", c.format, c.v, got, c.want)
+		}
+	}
+}
+
+func TestFormatBitMatrix(t *testing.T) {
+	bm := bitMatrix(0b1011, 0b0110, 0b0001)
+	am := &AugmentedBitMatrix{bm, 1}
+	cases := []struct {
+		format string
+		bm     any
+		want   string
+	}{
+		{"%v", &bm, "11\n06\n01\n"},
+		{"%s", &bm, "11\n06\n01\n"},
+		{"%b", &bm, "1011\n0110\n0001\n"},
+		{"%#b", &bm, "0b1011\n0b0110\n0b0001\n"},
+		{"%.6b", &bm, "001011\n000110\n000001\n"},
+		{"%x", &bm, "b\n6\n1\n"},
+		{"%3x", &bm, "  b\n  6\n  1\n"},
+		{"%03b", &bm, "1011\n0110\n0001\n"},
+		{"%05b", &bm, "01011\n00110\n00001\n"},
+		{"%d", &bm, "11\n06\n01\n"},
+		{"%+v", &bm, "1011\n0110\n0001\n"},
+		{"%+.0v", &bm, "1011\n0110\n0001\n"},
+		{"%+v", &AugmentedBitMatrix{bm, 0}, "1011\n0110\n0001\n"},
+		{"%+.2v", &bm, "10|11\n01|10\n00|01\n"},
+		{"%+v", am, "101|1\n011|0\n000|1\n"},
+		{"%b", am, "1011\n0110\n0001\n"},
+		{"%+v", &AugmentedBitMatrix{bitMatrix(1, 0), 3}, "|001\n|000\n"},
+		{"%q", &bm, "%!q(*gf2vs.BitMatrix=11\n06\n01\n)"},
+	}
+	for _, c := range cases {
+		if got := fmt.Sprintf(c.format, c.bm); got != c.want {
+			t.Errorf("Sprintf(%q, %v) = %q, want %q", c.format, c.bm, got, c.want)
+		}
+	}
+}
+
+func TestParseGF2Vector(t *testing.T) {
+	r := rand.New(rand.NewPCG(3, 4))
+	for _, dim := range []uint{1, 7, 64, 65, 200} {
+		s := NewGF2VectorSpace(dim)
+		for range 10 {
+			v := randomVector(r, s, 2)
+			for _, format := range []string{"%v", "%b", "%#b", "%O", "%#x", "%#X"} {
+				str := fmt.Sprintf(format, v)
+				got, err := ParseGF2Vector(s, str)
+				if err != nil || got.String() != v.String() {
+					t.Errorf("ParseGF2Vector(%v, %q) = %v, %v, want %v", dim, str, got, err, v)
+				}
+			}
+		}
+	}
+	s := NewGF2VectorSpace(4)
+	errs := []struct {
+		s    string
+		want error
+	}{
+		{"", ErrInvalidEncoding},
+		{"0b", ErrInvalidEncoding},
+		{"102", ErrInvalidEncoding},
+		{"-1", ErrInvalidEncoding},
+		{"+1", ErrInvalidEncoding},
+		{"0xg", ErrInvalidEncoding},
+		{"10000", ErrValueOutOfRange},
+		{"0x10", ErrValueOutOfRange},
+	}
+	for _, c := range errs {
+		if _, err := ParseGF2Vector(s, c.s); !errors.Is(err, c.want) {
+			t.Errorf("ParseGF2Vector(4, %q) = %v, want %v", c.s, err, c.want)
+		}
+	}
+}
+
+func TestParseBitMatrix(t *testing.T) {
+	bm := bitMatrix(0b1011, 0b0110, 0, 0b0001)
+	for _, base := range []int{2, 8, 10, 16, 36, 62} {
+		for _, sep := range []string{"\n", ",", "; "} {
+			text := bm.Text(base, sep)
+			got, err := ParseBitMatrix(text, base, sep)
+			if err != nil || got.Cmp(&bm) != 0 {
+				t.Errorf("ParseBitMatrix(%q, %v, %q) = %v, %v, want %v", text, base, sep, got, err, bm)
+			}
+		}
+	}
+	if got, err := ParseBitMatrix("101,11", 2, ","); err != nil || len(got) != 2 || got[1].Int64() != 3 {
+		t.Errorf("ParseBitMatrix(\"101,11\", 2, \",\") = %v, %v", got, err)
+	}
+	if got, err := ParseBitMatrix("", 2, ","); err != nil || len(got) != 0 {
+		t.Errorf("ParseBitMatrix(\"\", 2, \",\") = %v, %v", got, err)
+	}
+	errs := []struct {
+		s    string
+		base int
+		sep  string
+		want error
+	}{
+		{"101\n", 1, "\n", ErrValueOutOfRange},
+		{"101\n", 63, "\n", ErrValueOutOfRange},
+		{"101\n", 2, "", ErrValueOutOfRange},
+		{"101\n\n", 2, "\n", ErrInvalidEncoding},
+		{"12\n", 2, "\n", ErrInvalidEncoding},
+		{"-1\n", 2, "\n", ErrInvalidEncoding},
+	}
+	for _, c := range errs {
+		if _, err := ParseBitMatrix(c.s, c.base, c.sep); !errors.Is(err, c.want) {
+			t.Errorf("ParseBitMatrix(%q, %v, %q) = %v, want %v", c.s, c.base, c.sep, err, c.want)
+		}
+	}
+}
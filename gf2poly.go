@@ -0,0 +1,424 @@
+// Ralf Poeppel, 2026
+//
+// This file implements polynomials over GF(2), the ring GF(2)[x].
+// A polynomial is a vector of its coefficients, the addition is xor,
+// the multiplication is carry-less. The methods follow big.Int:
+// the receiver z is set to the result and returned, arguments may alias z.
+// Karatsuba multiplication:
+// https://en.wikipedia.org/w/index.php?title=Karatsuba_algorithm&oldid=1333573372
+
+package gf2vs
+
+import (
+	"fmt"
+	"math/big"
+	"math/bits"
+	"slices"
+	"strings"
+)
+
+// GF2Poly represents a polynomial over GF(2), the zero value is the zero polynomial.
+type GF2Poly struct {
+	w []uint // bit i of word k is the coefficient of x^(k*UintSize+i), no leading zero words
+}
+
+// karatsubaThreshold the count of words of both factors from which on
+// Karatsuba multiplication is used.
+var karatsubaThreshold = 16
+
+// NewGF2Poly create the polynomial with the coefficients of the exponents exps set,
+// NewGF2Poly(4, 1, 0) is x^4 + x + 1. A repeated exponent cancels out.
+func NewGF2Poly(exps ...uint) *GF2Poly {
+	z := new(GF2Poly)
+	for _, e := range exps {
+		z.SetCoeff(e, z.Coeff(e)^1)
+	}
+	return z
+}
+
+// NewGF2PolyWords create the polynomial with coefficients w,
+// bit i of w[k] is the coefficient of x^(k*UintSize+i). w is copied.
+func NewGF2PolyWords(w []uint) *GF2Poly {
+	z := &GF2Poly{slices.Clone(w)}
+	return z.norm()
+}
+
+// norm remove the leading zero words of z and return z.
+func (z *GF2Poly) norm() *GF2Poly {
+	n := len(z.w)
+	for n > 0 && z.w[n-1] == 0 {
+		n--
+	}
+	z.w = z.w[:n]
+	return z
+}
+
+// Words return a copy of the coefficients of p, see NewGF2PolyWords.
+func (p *GF2Poly) Words() []uint {
+	return slices.Clone(p.w)
+}
+
+// Degree return the degree of p, -1 for the zero polynomial.
+func (p *GF2Poly) Degree() int {
+	n := len(p.w)
+	if n == 0 {
+		return -1
+	}
+	return (n-1)*bits.UintSize + bits.Len(p.w[n-1]) - 1
+}
+
+// Coeff return the coefficient of x^i of p.
+func (p *GF2Poly) Coeff(i uint) uint {
+	k := i / bits.UintSize
+	if k >= uint(len(p.w)) {
+		return 0
+	}
+	return p.w[k] >> (i % bits.UintSize) & 1
+}
+
+// SetCoeff set the coefficient of x^i of z to c and return z,
+// c must be 0 or 1.
+func (z *GF2Poly) SetCoeff(i uint, c uint) *GF2Poly {
+	k := int(i / bits.UintSize)
+	if c&1 == 0 {
+		if k < len(z.w) {
+			z.w[k] &^= 1 << (i % bits.UintSize)
+			z.norm()
+		}
+		return z
+	}
+	if k >= len(z.w) {
+		z.w = append(z.w, make([]uint, k+1-len(z.w))...)
+	}
+	z.w[k] |= 1 << (i % bits.UintSize)
+	return z
+}
+
+// Weight return the count of coefficients of p set.
+func (p *GF2Poly) Weight() int {
+	c := 0
+	for _, w := range p.w {
+		c += bits.OnesCount(w)
+	}
+	return c
+}
+
+// IsZero return true if p is the zero polynomial.
+func (p *GF2Poly) IsZero() bool {
+	return len(p.w) == 0
+}
+
+// IsOne return true if p is the polynomial 1.
+func (p *GF2Poly) IsOne() bool {
+	return len(p.w) == 1 && p.w[0] == 1
+}
+
+// Cmp compares p and q as numbers with the coefficients as binary digits and returns:
+//   - -1 if p < q;
+//   - 0 if p == q;
+//   - +1 if p > q.
+//
+// A polynomial of smaller degree is smaller.
+func (p *GF2Poly) Cmp(q *GF2Poly) int {
+	if c := len(p.w) - len(q.w); c != 0 {
+		return min(max(c, -1), 1)
+	}
+	for k := len(p.w) - 1; k >= 0; k-- {
+		switch {
+		case p.w[k] < q.w[k]:
+			return -1
+		case p.w[k] > q.w[k]:
+			return 1
+		}
+	}
+	return 0
+}
+
+// Equal return true if p and q are the same polynomial.
+func (p *GF2Poly) Equal(q *GF2Poly) bool {
+	return slices.Equal(p.w, q.w)
+}
+
+// String return p as sum of powers of x, descending, e.g. "x^4 + x + 1".
+func (p *GF2Poly) String() string {
+	if p.IsZero() {
+		return "0"
+	}
+	var sb strings.Builder
+	for i := p.Degree(); i >= 0; i-- {
+		if p.Coeff(uint(i)) == 0 {
+			continue
+		}
+		if sb.Len() > 0 {
+			sb.WriteString(" + ")
+		}
+		switch i {
+		case 0:
+			sb.WriteString("1")
+		case 1:
+			sb.WriteString("x")
+		default:
+			fmt.Fprintf(&sb, "x^%v", i)
+		}
+	}
+	return sb.String()
+}
+
+// Set sets z = x and returns z.
+func (z *GF2Poly) Set(x *GF2Poly) *GF2Poly {
+	if z != x {
+		z.w = append(z.w[:0], x.w...)
+	}
+	return z
+}
+
+// Add sets z = x + y and returns z, the sum is the xor of the coefficients.
+// It is also the difference x - y.
+func (z *GF2Poly) Add(x, y *GF2Poly) *GF2Poly {
+	if len(x.w) < len(y.w) {
+		x, y = y, x
+	}
+	w := z.w
+	if cap(w) < len(x.w) {
+		w = make([]uint, len(x.w))
+	}
+	w = w[:len(x.w)]
+	for i, a := range x.w {
+		if i < len(y.w) {
+			a ^= y.w[i]
+		}
+		w[i] = a
+	}
+	z.w = w
+	return z.norm()
+}
+
+// clmul return the carry-less product of a and b.
+func clmul(a, b uint) (hi, lo uint) {
+	for b != 0 {
+		i := bits.TrailingZeros(b)
+		lo ^= a << i
+		if i > 0 {
+			hi ^= a >> (bits.UintSize - i)
+		}
+		b &= b - 1
+	}
+	return hi, lo
+}
+
+// xorInto set dst ^= src, the words of src beyond dst must be zero.
+func xorInto(dst, src []uint) {
+	for i := range min(len(dst), len(src)) {
+		dst[i] ^= src[i]
+	}
+}
+
+// xorWords return x ^ y with the length of the longer slice.
+func xorWords(x, y []uint) []uint {
+	if len(x) < len(y) {
+		x, y = y, x
+	}
+	z := slices.Clone(x)
+	xorInto(z, y)
+	return z
+}
+
+// splitWords return the m least significant words of x and the remaining words.
+func splitWords(x []uint, m int) ([]uint, []uint) {
+	m = min(m, len(x))
+	return x[:m], x[m:]
+}
+
+// mulWords return the carry-less product of x and y with len(x)+len(y) words.
+// The product is computed by Karatsuba multiplication for large factors.
+func mulWords(x, y []uint) []uint {
+	z := make([]uint, len(x)+len(y))
+	if n := min(len(x), len(y)); n < karatsubaThreshold || n < 2 {
+		for i, a := range x {
+			if a == 0 {
+				continue
+			}
+			for j, b := range y {
+				hi, lo := clmul(a, b)
+				z[i+j] ^= lo
+				z[i+j+1] ^= hi
+			}
+		}
+		return z
+	}
+	// x*y = z2*X^2m + z1*X^m + z0 with X = 2^UintSize
+	m := max(len(x), len(y)) / 2
+	x0, x1 := splitWords(x, m)
+	y0, y1 := splitWords(y, m)
+	z0 := mulWords(x0, y0)
+	z2 := mulWords(x1, y1)
+	z1 := mulWords(xorWords(x0, x1), xorWords(y0, y1))
+	xorInto(z1, z0)
+	xorInto(z1, z2)
+	xorInto(z, z0)
+	xorInto(z[m:], z1)
+	xorInto(z[2*m:], z2)
+	return z
+}
+
+// Mul sets z = x * y and returns z, the carry-less product.
+func (z *GF2Poly) Mul(x, y *GF2Poly) *GF2Poly {
+	z.w = mulWords(x.w, y.w)
+	return z.norm()
+}
+
+// spread return the bits of the lower half of x at the even positions.
+func spread(x uint64) uint64 {
+	x &= 0xffffffff
+	x = (x | x<<16) & 0x0000ffff0000ffff
+	x = (x | x<<8) & 0x00ff00ff00ff00ff
+	x = (x | x<<4) & 0x0f0f0f0f0f0f0f0f
+	x = (x | x<<2) & 0x3333333333333333
+	x = (x | x<<1) & 0x5555555555555555
+	return x
+}
+
+// Sqr sets z = x * x and returns z. Over GF(2) the square of a sum is the
+// sum of the squares, so the coefficient of x^i moves to x^2i.
+func (z *GF2Poly) Sqr(x *GF2Poly) *GF2Poly {
+	const half = bits.UintSize / 2
+	w := make([]uint, 2*len(x.w))
+	for i, a := range x.w {
+		w[2*i] = uint(spread(uint64(a) & (1<<half - 1)))
+		w[2*i+1] = uint(spread(uint64(a) >> half))
+	}
+	z.w = w
+	return z.norm()
+}
+
+// Derivative sets z to the formal derivative of x and returns z.
+// Over GF(2) the coefficient i * c_i of x^(i-1) is c_i for odd i, else 0.
+func (z *GF2Poly) Derivative(x *GF2Poly) *GF2Poly {
+	const odd = ^uint(0) / 3 << 1 // 0b1010...10
+	w := make([]uint, len(x.w))
+	for i, a := range x.w {
+		w[i] = a & odd >> 1
+	}
+	z.w = w
+	return z.norm()
+}
+
+// Lsh sets z = x * x^n and returns z.
+func (z *GF2Poly) Lsh(x *GF2Poly, n uint) *GF2Poly {
+	if x.IsZero() {
+		z.w = z.w[:0]
+		return z
+	}
+	k, s := int(n/bits.UintSize), n%bits.UintSize
+	w := make([]uint, len(x.w)+k+1)
+	for i, a := range x.w {
+		w[i+k] |= a << s
+		if s > 0 {
+			w[i+k+1] = a >> (bits.UintSize - s)
+		}
+	}
+	z.w = w
+	return z.norm()
+}
+
+// xorShifted set r ^= y * x^s, the words of the product beyond r must be zero.
+func xorShifted(r, y []uint, s int) {
+	k, b := s/bits.UintSize, uint(s%bits.UintSize)
+	for i, a := range y {
+		r[i+k] ^= a << b
+		if b > 0 && i+k+1 < len(r) {
+			r[i+k+1] ^= a >> (bits.UintSize - b)
+		}
+	}
+}
+
+// DivMod sets z to the quotient x div y and m to the remainder x mod y,
+// deg(m) < deg(y), and returns the pair (z, m).
+// Panic if y is the zero polynomial.
+func (z *GF2Poly) DivMod(x, y, m *GF2Poly) (*GF2Poly, *GF2Poly) {
+	if y.IsZero() {
+		panic("DivMod(x, y, m): division by zero polynomial")
+	}
+	if z == m {
+		panic("DivMod(x, y, m): z and m are the same polynomial")
+	}
+	dy := y.Degree()
+	r := slices.Clone(x.w)
+	dr := x.Degree()
+	var q []uint
+	if dr >= dy {
+		q = make([]uint, (dr-dy)/bits.UintSize+1)
+	}
+	for ; dr >= dy; dr = (&GF2Poly{r}).norm().Degree() {
+		s := dr - dy
+		q[s/bits.UintSize] |= 1 << (s % bits.UintSize)
+		xorShifted(r, y.w, s)
+	}
+	m.w = (&GF2Poly{r}).norm().w
+	z.w = q
+	z.norm()
+	return z, m
+}
+
+// Div sets z to the quotient x div y and returns z.
+// Panic if y is the zero polynomial.
+func (z *GF2Poly) Div(x, y *GF2Poly) *GF2Poly {
+	z.DivMod(x, y, new(GF2Poly))
+	return z
+}
+
+// Mod sets z to the remainder x mod y and returns z.
+// Panic if y is the zero polynomial.
+func (z *GF2Poly) Mod(x, y *GF2Poly) *GF2Poly {
+	new(GF2Poly).DivMod(x, y, z)
+	return z
+}
+
+// GCD sets z to the greatest common divisor of a and b and returns z.
+// If s and t are not nil, GCD sets them such that z = a*s + b*t,
+// the extended Euclidean algorithm. The GCD of 0 and 0 is 0.
+func (z *GF2Poly) GCD(s, t, a, b *GF2Poly) *GF2Poly {
+	// invariants: r0 = a*s0 + b*t0, r1 = a*s1 + b*t1
+	r0, r1 := new(GF2Poly).Set(a), new(GF2Poly).Set(b)
+	s0, s1 := NewGF2Poly(0), new(GF2Poly)
+	t0, t1 := new(GF2Poly), NewGF2Poly(0)
+	q, r, u := new(GF2Poly), new(GF2Poly), new(GF2Poly)
+	for !r1.IsZero() {
+		q.DivMod(r0, r1, r)
+		r0, r1, r = r1, r, r0
+		u.Mul(q, s1)
+		s0, s1, u = s1, u.Add(u, s0), s0
+		u.Mul(q, t1)
+		t0, t1, u = t1, u.Add(u, t0), t0
+	}
+	if s != nil {
+		s.Set(s0)
+	}
+	if t != nil {
+		t.Set(t0)
+	}
+	return z.Set(r0)
+}
+
+// MulMod sets z = x * y mod m and returns z.
+// Panic if m is the zero polynomial.
+func (z *GF2Poly) MulMod(x, y, m *GF2Poly) *GF2Poly {
+	return z.Mod(z.Mul(x, y), m)
+}
+
+// ModExp sets z = x**e mod m and returns z, computed by square and multiply.
+// Panic if m is the zero polynomial or e is negative.
+func (z *GF2Poly) ModExp(x *GF2Poly, e *big.Int, m *GF2Poly) *GF2Poly {
+	if e.Sign() < 0 {
+		panic(fmt.Sprintf("ModExp(x, e, m): e = %v is negative", e))
+	}
+	b := new(GF2Poly).Mod(x, m)
+	r := new(GF2Poly).Mod(NewGF2Poly(0), m)
+	for i := e.BitLen() - 1; i >= 0; i-- {
+		r.Mod(r.Sqr(r), m)
+		if e.Bit(i) == 1 {
+			r.MulMod(r, b, m)
+		}
+	}
+	return z.Set(r)
+}
@@ -0,0 +1,282 @@
+// Ralf Poeppel, 2026
+
+package gf2vs
+
+import (
+	"math/big"
+	"math/bits"
+	"math/rand/v2"
+	"testing"
+)
+
+// randomPoly return a random polynomial of at most n words.
+func randomPoly(r *rand.Rand, n int) *GF2Poly {
+	w := make([]uint, n)
+	for i := range w {
+		w[i] = uint(r.Uint64())
+	}
+	return NewGF2PolyWords(w)
+}
+
+func TestGF2PolyString(t *testing.T) {
+	cases := []struct {
+		p      *GF2Poly
+		want   string
+		degree int
+	}{
+		{new(GF2Poly), "0", -1},
+		{NewGF2Poly(0), "1", 0},
+		{NewGF2Poly(1), "x", 1},
+		{NewGF2Poly(4, 1, 0), "x^4 + x + 1", 4},
+		{NewGF2Poly(0, 1, 4), "x^4 + x + 1", 4},
+		{NewGF2Poly(3, 3, 2), "x^2", 2},
+		{NewGF2Poly(64, 0), "x^64 + 1", 64},
+		{NewGF2PolyWords([]uint{0b1011, 0, 0}), "x^3 + x + 1", 3},
+		{NewGF2PolyWords([]uint{0, 0}), "0", -1},
+	}
+	for _, c := range cases {
+		if got := c.p.String(); got != c.want {
+			t.Errorf("String() = %v, want %v", got, c.want)
+		}
+		if got := c.p.Degree(); got != c.degree {
+			t.Errorf("%v.Degree() = %v, want %v", c.p, got, c.degree)
+		}
+	}
+}
+
+func TestGF2PolyCoeff(t *testing.T) {
+	p := NewGF2Poly(130, 5)
+	if p.Coeff(130) != 1 || p.Coeff(5) != 1 || p.Coeff(6) != 0 || p.Coeff(1000) != 0 {
+		t.Errorf("Coeff of %v", p)
+	}
+	p.SetCoeff(130, 0)
+	if p.Degree() != 5 || len(p.Words()) != 1 {
+		t.Errorf("SetCoeff(130, 0) = %v, words %v", p, p.Words())
+	}
+	p.SetCoeff(1000, 0).SetCoeff(0, 1)
+	if got := p.String(); got != "x^5 + 1" {
+		t.Errorf("SetCoeff(0, 1) = %v, want x^5 + 1", got)
+	}
+	if !NewGF2Poly(0).IsOne() || NewGF2Poly(1).IsOne() || !new(GF2Poly).IsZero() {
+		t.Errorf("IsOne, IsZero")
+	}
+}
+
+func TestGF2PolyCmp(t *testing.T) {
+	cases := []struct {
+		p, q *GF2Poly
+		want int
+	}{
+		{new(GF2Poly), new(GF2Poly), 0},
+		{new(GF2Poly), NewGF2Poly(0), -1},
+		{NewGF2Poly(4, 1, 0), NewGF2Poly(4, 3, 0), -1},
+		{NewGF2Poly(70), NewGF2Poly(4, 3, 0), 1},
+		{NewGF2Poly(70, 1), NewGF2Poly(70, 0), 1},
+		{NewGF2Poly(70, 1), NewGF2Poly(1, 70), 0},
+	}
+	for _, c := range cases {
+		if got := c.p.Cmp(c.q); got != c.want {
+			t.Errorf("%v.Cmp(%v) = %v, want %v", c.p, c.q, got, c.want)
+		}
+		if got := c.p.Equal(c.q); got != (c.want == 0) {
+			t.Errorf("%v.Equal(%v) = %v", c.p, c.q, got)
+		}
+	}
+}
+
+func TestGF2PolyAddMul(t *testing.T) {
+	cases := []struct {
+		x, y     *GF2Poly
+		sum, prd string
+	}{
+		{new(GF2Poly), NewGF2Poly(2, 0), "x^2 + 1", "0"},
+		{NewGF2Poly(1, 0), NewGF2Poly(1, 0), "0", "x^2 + 1"},
+		{NewGF2Poly(2, 1, 0), NewGF2Poly(1, 0), "x^2", "x^3 + 1"},
+		{NewGF2Poly(63), NewGF2Poly(63, 1), "x", "x^126 + x^64"},
+		{NewGF2Poly(64, 0), NewGF2Poly(64, 0), "0", "x^128 + 1"},
+	}
+	for _, c := range cases {
+		if got := new(GF2Poly).Add(c.x, c.y); got.String() != c.sum {
+			t.Errorf("Add(%v, %v) = %v, want %v", c.x, c.y, got, c.sum)
+		}
+		if got := new(GF2Poly).Mul(c.x, c.y); got.String() != c.prd {
+			t.Errorf("Mul(%v, %v) = %v, want %v", c.x, c.y, got, c.prd)
+		}
+		if got := new(GF2Poly).Set(c.x); got.Mul(got, got).String() != new(GF2Poly).Sqr(c.x).String() {
+			t.Errorf("Sqr(%v) = %v, want %v", c.x, new(GF2Poly).Sqr(c.x), got)
+		}
+	}
+}
+
+// clmulBits return the carry-less product of a and b bit by bit.
+func clmulBits(a, b uint) (hi, lo uint) {
+	for i := range bits.UintSize {
+		for j := range bits.UintSize {
+			if a>>i&1 == 1 && b>>j&1 == 1 {
+				if k := i + j; k < bits.UintSize {
+					lo ^= 1 << k
+				} else {
+					hi ^= 1 << (k - bits.UintSize)
+				}
+			}
+		}
+	}
+	return hi, lo
+}
+
+func TestClmul(t *testing.T) {
+	r := rand.New(rand.NewPCG(1, 1))
+	for range 100 {
+		a, b := uint(r.Uint64()), uint(r.Uint64())
+		hi, lo := clmul(a, b)
+		whi, wlo := clmulBits(a, b)
+		if hi != whi || lo != wlo {
+			t.Errorf("clmul(%x, %x) = %x %x, want %x %x", a, b, hi, lo, whi, wlo)
+		}
+	}
+}
+
+func TestKaratsuba(t *testing.T) {
+	r := rand.New(rand.NewPCG(2, 2))
+	defer func(k int) { karatsubaThreshold = k }(karatsubaThreshold)
+	for _, n := range [][2]int{{16, 16}, {17, 40}, {33, 33}, {100, 20}, {64, 65}} {
+		x, y := randomPoly(r, n[0]), randomPoly(r, n[1])
+		karatsubaThreshold = 1 << 30
+		want := new(GF2Poly).Mul(x, y)
+		for _, k := range []int{1, 2, 16} {
+			karatsubaThreshold = k
+			if got := new(GF2Poly).Mul(x, y); !got.Equal(want) {
+				t.Errorf("Karatsuba threshold %v: Mul of %v x %v words failed", k, n[0], n[1])
+			}
+		}
+	}
+}
+
+func TestGF2PolyDivMod(t *testing.T) {
+	cases := []struct {
+		x, y   *GF2Poly
+		q, rem string
+	}{
+		{NewGF2Poly(3, 0), NewGF2Poly(1, 0), "x^2 + x + 1", "0"},
+		{NewGF2Poly(4), NewGF2Poly(4, 1, 0), "1", "x + 1"},
+		{NewGF2Poly(2), NewGF2Poly(4, 1, 0), "0", "x^2"},
+		{new(GF2Poly), NewGF2Poly(1), "0", "0"},
+		{NewGF2Poly(5, 0), NewGF2Poly(0), "x^5 + 1", "0"},
+	}
+	for _, c := range cases {
+		q, rem := new(GF2Poly).DivMod(c.x, c.y, new(GF2Poly))
+		if q.String() != c.q || rem.String() != c.rem {
+			t.Errorf("DivMod(%v, %v) = %v, %v, want %v, %v", c.x, c.y, q, rem, c.q, c.rem)
+		}
+	}
+
+	r := rand.New(rand.NewPCG(3, 3))
+	for _, n := range [][2]int{{1, 1}, {3, 1}, {5, 2}, {10, 7}, {4, 6}} {
+		x, y := randomPoly(r, n[0]), randomPoly(r, n[1])
+		q, rem := new(GF2Poly).DivMod(x, y, new(GF2Poly))
+		back := new(GF2Poly).Mul(q, y)
+		back.Add(back, rem)
+		if !back.Equal(x) || rem.Degree() >= y.Degree() {
+			t.Errorf("DivMod(%v, %v) = %v, %v", x, y, q, rem)
+		}
+		if d := new(GF2Poly).Div(x, y); !d.Equal(q) {
+			t.Errorf("Div(%v, %v) = %v, want %v", x, y, d, q)
+		}
+		// aliasing of z and x
+		if m := new(GF2Poly).Set(x); !m.Mod(m, y).Equal(rem) {
+			t.Errorf("Mod(%v, %v) = %v, want %v", x, y, m, rem)
+		}
+	}
+
+	defer func() {
+		want := "DivMod(x, y, m): division by zero polynomial"
+		if r := recover(); r != want {
+			t.Errorf("DivMod panic %v, want %v", r, want)
+		}
+	}()
+	new(GF2Poly).Mod(NewGF2Poly(1), new(GF2Poly))
+}
+
+func TestGF2PolyGCD(t *testing.T) {
+	cases := []struct {
+		a, b *GF2Poly
+		want string
+	}{
+		{new(GF2Poly), new(GF2Poly), "0"},
+		{NewGF2Poly(2, 0), new(GF2Poly), "x^2 + 1"},
+		{new(GF2Poly), NewGF2Poly(2, 0), "x^2 + 1"},
+		{NewGF2Poly(2, 0), NewGF2Poly(3, 0), "x + 1"},
+		{NewGF2Poly(4, 1, 0), NewGF2Poly(4, 3, 0), "1"},
+	}
+	for _, c := range cases {
+		s, t2 := new(GF2Poly), new(GF2Poly)
+		g := new(GF2Poly).GCD(s, t2, c.a, c.b)
+		if g.String() != c.want {
+			t.Errorf("GCD(%v, %v) = %v, want %v", c.a, c.b, g, c.want)
+		}
+		sum := new(GF2Poly).Add(new(GF2Poly).Mul(c.a, s), new(GF2Poly).Mul(c.b, t2))
+		if !sum.Equal(g) {
+			t.Errorf("GCD(%v, %v): %v * %v + %v * %v = %v, want %v", c.a, c.b, c.a, s, c.b, t2, sum, g)
+		}
+	}
+
+	r := rand.New(rand.NewPCG(4, 4))
+	for range 20 {
+		f, a, b := randomPoly(r, 1), randomPoly(r, 2), randomPoly(r, 3)
+		a.Mul(a, f)
+		b.Mul(b, f)
+		s, t2 := new(GF2Poly), new(GF2Poly)
+		g := new(GF2Poly).GCD(s, t2, a, b)
+		if !new(GF2Poly).Mod(a, g).IsZero() || !new(GF2Poly).Mod(b, g).IsZero() ||
+			!new(GF2Poly).Mod(g, f).IsZero() {
+			t.Errorf("GCD(%v, %v) = %v, no multiple of %v", a, b, g, f)
+		}
+		sum := new(GF2Poly).Add(new(GF2Poly).Mul(a, s), new(GF2Poly).Mul(b, t2))
+		if !sum.Equal(g) {
+			t.Errorf("GCD(%v, %v) = %v: a*s + b*t = %v", a, b, g, sum)
+		}
+		if g2 := a.GCD(nil, nil, a, b); !g2.Equal(g) {
+			t.Errorf("a.GCD(nil, nil, a, b) = %v, want %v", g2, g)
+		}
+	}
+}
+
+func TestGF2PolyModExp(t *testing.T) {
+	m := NewGF2Poly(4, 1, 0)
+	x := NewGF2Poly(1)
+	cases := []struct {
+		x    *GF2Poly
+		e    int64
+		m    *GF2Poly
+		want string
+	}{
+		{x, 0, m, "1"},
+		{x, 1, m, "x"},
+		{x, 4, m, "x + 1"},
+		{x, 15, m, "1"},
+		{x, 16, m, "x"},
+		{x, 5, NewGF2Poly(4, 3, 2, 1, 0), "1"},
+		{x, 7, NewGF2Poly(0), "0"},
+		{new(GF2Poly), 0, m, "1"},
+		{NewGF2Poly(3, 1), 1024, m, new(GF2Poly).Mod(NewGF2Poly(3072, 1024), m).String()},
+	}
+	for _, c := range cases {
+		if got := new(GF2Poly).ModExp(c.x, big.NewInt(c.e), c.m); got.String() != c.want {
+			t.Errorf("ModExp(%v, %v, %v) = %v, want %v", c.x, c.e, c.m, got, c.want)
+		}
+	}
+	// repeated multiplication
+	y, m9, want := NewGF2Poly(7, 3, 1), NewGF2Poly(9, 4, 0), NewGF2Poly(0)
+	for e := range int64(100) {
+		if got := new(GF2Poly).ModExp(y, big.NewInt(e), m9); !got.Equal(want) {
+			t.Errorf("ModExp(%v, %v, %v) = %v, want %v", y, e, m9, got, want)
+		}
+		want.MulMod(want, y, m9)
+	}
+	// Fermat for a field with 2**127 elements, x^127 + x + 1 is irreducible
+	m = NewGF2Poly(127, 1, 0)
+	e := new(big.Int).Lsh(big.NewInt(1), 127)
+	if got := new(GF2Poly).ModExp(x, e, m); !got.Equal(x) {
+		t.Errorf("ModExp(x, 2**127, %v) = %v, want x", m, got)
+	}
+}
@@ -13,38 +13,97 @@
 // of the vector. Some times this function is named popcount. It is the result of the scalar product.
 // Sub vector spaces are supported too. A set of vectors may be a span of a sub vector space. This
 // property is verified.
+// The constructors and operators panic on invalid arguments. Each has a
+// counterpart named with the suffix Err, e.g. NewGF2VectorSpaceErr, which
+// returns a VectorSpaceError instead, test it with errors.Is and errors.As.
 package gf2vs
 
 import (
+	"errors"
 	"fmt"
+	"iter"
 	"math/bits"
+	"strings"
 )
 
+// The errors wrapped by VectorSpaceError, test them with errors.Is.
+var (
+	// ErrDimensionMismatch vectors of vector spaces of different dimension are combined.
+	ErrDimensionMismatch = errors.New("incompatible vector spaces")
+	// ErrValueOutOfRange a dimension, index, value or count of arguments is out of range.
+	ErrValueOutOfRange = errors.New("value out of range")
+	// ErrInvalidEncoding the data to unmarshal or parse is no valid encoding.
+	ErrInvalidEncoding = errors.New("invalid encoding")
+	// ErrSingularMatrix the matrix to invert is singular.
+	ErrSingularMatrix = errors.New("singular matrix")
+)
+
+// VectorSpaceError holds the error messages of the vector space functions.
+// The message is the same as the panic message of the panicking variant.
+type VectorSpaceError struct {
+	Op   string // function which failed
+	What string // description of the failure
+	Err  error  // ErrDimensionMismatch, ErrValueOutOfRange, ErrInvalidEncoding or ErrSingularMatrix
+}
+
+// Error return the error messages as string.
+func (e *VectorSpaceError) Error() string {
+	return e.Op + ": " + e.What
+}
+
+// Unwrap return the wrapped error ErrDimensionMismatch, ErrValueOutOfRange,
+// ErrInvalidEncoding or ErrSingularMatrix.
+func (e *VectorSpaceError) Unwrap() error {
+	return e.Err
+}
+
+// outOfRange return a VectorSpaceError wrapping ErrValueOutOfRange.
+func outOfRange(op string, format string, a ...any) error {
+	return &VectorSpaceError{op, fmt.Sprintf(format, a...), ErrValueOutOfRange}
+}
+
 // GF2VectorSpace represents a vector space of size n over GF(2).
+// Spaces of dimension up to bits.UintSize hold each vector in a single uint,
+// larger spaces use a slice of words, least significant word first.
 type GF2VectorSpace struct {
 	dim  uint // dimension of the vector space
-	ones uint // bitvector where all bit are set, no zeros as allways 0
+	ones uint // bitvector where all bit of the most significant word are set, no zeros as allways 0
 }
 
 // NewGF2VectorSpace create a vector space of dimension n.
 // Return a pointer, as only a pointer has a null value, but a struct not.
-// Panic if n is out of range.
+// Panic if n is out of range, it is NewGF2VectorSpaceErr panicking with its error.
 func NewGF2VectorSpace(n uint) *GF2VectorSpace {
-	if n < 1 {
-		panic(fmt.Sprintf("NewGF2VectorSpace(dim): dim = %v < 1", n))
-	}
-	if n > bits.UintSize {
-		panic(fmt.Sprintf("NewGF2VectorSpace(dim): dim = %v > %v = bits.UintSize", n, bits.UintSize))
+	sp, err := NewGF2VectorSpaceErr(n)
+	if err != nil {
+		panic(err.Error())
 	}
+	return sp
+}
 
-	ones := uint(1)
-	for i := uint(1); i < n; i++ {
-		ones <<= 1
-		ones += 1
+// NewGF2VectorSpaceErr create a vector space of dimension n.
+// Return an error wrapping ErrValueOutOfRange if n is out of range.
+func NewGF2VectorSpaceErr(n uint) (*GF2VectorSpace, error) {
+	if n < 1 {
+		return nil, outOfRange("NewGF2VectorSpace(dim)", "dim = %v < 1", n)
 	}
 
+	// bits used in the most significant word
+	top := (n-1)%bits.UintSize + 1
+	ones := ^uint(0) >> (bits.UintSize - top)
+
 	sp := GF2VectorSpace{n, ones}
-	return &sp
+	return &sp, nil
+}
+
+// isWide return true if the vectors of s need more than one word.
+func (s *GF2VectorSpace) isWide() bool {
+	return s.dim > bits.UintSize
+}
+
+// words return the count of words of a vector in s.
+func (s *GF2VectorSpace) words() int {
+	return int((s.dim + bits.UintSize - 1) / bits.UintSize)
 }
 
 func (sp *GF2VectorSpace) String() string {
@@ -54,68 +113,208 @@ func (sp *GF2VectorSpace) String() string {
 // GF2SubVectorSpace represents a sub vector space
 type GF2SubVectorSpace struct {
 	GF2VectorSpace
-	subOnes uint // bitvector where the bits of the base are set
+	subOnes  uint   // bitvector where the bits of the base are set
+	subWords []uint // bits of the base in a wide vector space, subOnes is unused
 }
 
 // NewGF2SubVectorSpace create a sub vector space with base bits b as sub space
 // of a vector space with dimension n.
 // Panic if b > n.
 // We call internally NewGF2VectorSpace which may panic for n out of range.
+// It is NewGF2SubVectorSpaceErr panicking with its error.
 func NewGF2SubVectorSpace(n, b uint) *GF2SubVectorSpace {
+	svs, err := NewGF2SubVectorSpaceErr(n, b)
+	if err != nil {
+		panic(err.Error())
+	}
+	return svs
+}
+
+// NewGF2SubVectorSpaceErr create a sub vector space with base bits b as sub space
+// of a vector space with dimension n.
+// Return an error wrapping ErrValueOutOfRange if b > n or n is out of range.
+func NewGF2SubVectorSpaceErr(n, b uint) (*GF2SubVectorSpace, error) {
 	if b > n {
-		panic(fmt.Sprintf("NewGF2SubVectorSpace(dim): base %v not in space with dim = %v", b, n))
+		return nil, outOfRange("NewGF2SubVectorSpace(dim)",
+			"base %v not in space with dim = %v", b, n)
+	}
+	vs, err := NewGF2VectorSpaceErr(n)
+	if err != nil {
+		return nil, err
+	}
+	svs := GF2SubVectorSpace{*vs, b, nil}
+	if vs.isWide() {
+		svs.subOnes = 0
+		svs.subWords = vs.NewGF2Vector(b).words
 	}
-	vs := NewGF2VectorSpace(n)
-	svs := GF2SubVectorSpace{*vs, b}
-	return &svs
+	return &svs, nil
 }
 
 func (sp *GF2SubVectorSpace) String() string {
+	if sp.isWide() {
+		sub := GF2Vector{sp: &sp.GF2VectorSpace, words: sp.subWords}
+		return fmt.Sprintf("GF(2)ssp{%v: %v, %v}", sp.dim, sp.ones, &sub)
+	}
 	return fmt.Sprintf("GF(2)ssp{%v: %v, %v}", sp.dim, sp.ones, sp.subOnes)
 }
 
 // GF2vector represents a vector in GF(2^n) a bitvector of len n,
 // in a vector space of dim n.
 type GF2Vector struct {
-	sp  *GF2VectorSpace // the space of this vector
-	val uint            // value of the vector
+	sp    *GF2VectorSpace // the space of this vector
+	val   uint            // value of the vector
+	words []uint          // value of a vector of a wide space, val is unused
 }
 
 // Val return the value as int.
 // If no valid v is given we return 0.
+// For a vector of a wide space Val truncates the value to the least
+// significant word, use ValErr to detect this, and Words or Bit to read
+// the whole value.
 func (v *GF2Vector) Val() uint {
 	if v == nil {
 		return 0
 	}
+	if v.words != nil {
+		return v.words[0]
+	}
 	return v.val
 }
 
+// ValErr return the value as int.
+// Return an error wrapping ErrValueOutOfRange if v is of a wide space of
+// dimension greater than bits.UintSize, the value may not fit in a uint.
+func (v *GF2Vector) ValErr() (uint, error) {
+	if v.words != nil {
+		return 0, outOfRange("ValErr()", "dim = %v > %v", v.sp.dim, bits.UintSize)
+	}
+	return v.val, nil
+}
+
+// Words return a copy of the value as slice of words,
+// least significant word first.
+func (v *GF2Vector) Words() []uint {
+	if v.words == nil {
+		return []uint{v.val}
+	}
+	w := make([]uint, len(v.words))
+	copy(w, v.words)
+	return w
+}
+
+// wordsOf return the value of v as slice of words, least significant word first.
+// The slice is shared with v for vectors of a wide space.
+func wordsOf(v *GF2Vector) []uint {
+	if v.words == nil {
+		return []uint{v.val}
+	}
+	return v.words
+}
+
 // String returns a string representing
 func (v *GF2Vector) String() string {
-	return fmt.Sprintf("%0[1]*[2]b", v.sp.dim, v.val)
+	if v.words == nil {
+		return fmt.Sprintf("%0[1]*[2]b", v.sp.dim, v.val)
+	}
+	n := len(v.words) - 1
+	top := v.sp.dim - uint(n)*bits.UintSize
+	var sb strings.Builder
+	sb.Grow(int(v.sp.dim))
+	fmt.Fprintf(&sb, "%0[1]*[2]b", top, v.words[n])
+	for i := n - 1; i >= 0; i-- {
+		fmt.Fprintf(&sb, "%0[1]*[2]b", bits.UintSize, v.words[i])
+	}
+	return sb.String()
 }
 
 // NewGF2Vector create a vector with value in vector space,
 // value must be greater equal 0.
+// In a wide space value is the least significant word of the vector.
+// Panic if value is out of range, it is NewGF2VectorErr panicking with its error.
 func (s *GF2VectorSpace) NewGF2Vector(value uint) *GF2Vector {
+	v, err := s.NewGF2VectorErr(value)
+	if err != nil {
+		panic(err.Error())
+	}
+	return v
+}
+
+// NewGF2VectorErr create a vector with value in vector space.
+// Return an error wrapping ErrValueOutOfRange if value is out of range.
+func (s *GF2VectorSpace) NewGF2VectorErr(value uint) (*GF2Vector, error) {
+	if s.isWide() {
+		w := make([]uint, s.words())
+		w[0] = value
+		return &GF2Vector{sp: s, words: w}, nil
+	}
 	vmx := (uint(1) << s.dim) - 1
 	if value > vmx {
-		panic(fmt.Sprintf("NewGF2Vector(value): value = %v > %v", value, vmx))
+		return nil, outOfRange("NewGF2Vector(value)", "value = %v > %v", value, vmx)
 	}
 
-	v := GF2Vector{s, value}
-	return &v
+	v := GF2Vector{sp: s, val: value}
+	return &v, nil
+}
+
+// NewGF2VectorWords create a vector with the value given as slice of words,
+// least significant word first. Missing words are zero.
+// Panic if there are more words as needed, or value is out of range.
+// It is NewGF2VectorWordsErr panicking with its error.
+func (s *GF2VectorSpace) NewGF2VectorWords(w []uint) *GF2Vector {
+	v, err := s.NewGF2VectorWordsErr(w)
+	if err != nil {
+		panic(err.Error())
+	}
+	return v
+}
+
+// NewGF2VectorWordsErr create a vector with the value given as slice of words.
+// Return an error wrapping ErrValueOutOfRange if there are more words as
+// needed, or value is out of range.
+func (s *GF2VectorSpace) NewGF2VectorWordsErr(w []uint) (*GF2Vector, error) {
+	n := s.words()
+	if len(w) > n {
+		return nil, outOfRange("NewGF2VectorWords(w)", "len(w) = %v > %v", len(w), n)
+	}
+	if len(w) == n && w[n-1] > s.ones {
+		return nil, outOfRange("NewGF2VectorWords(w)", "w[%v] = %v > %v", n-1, w[n-1], s.ones)
+	}
+	if !s.isWide() {
+		v := GF2Vector{sp: s}
+		if len(w) > 0 {
+			v.val = w[0]
+		}
+		return &v, nil
+	}
+	v := GF2Vector{sp: s, words: make([]uint, n)}
+	copy(v.words, w)
+	return &v, nil
 }
 
 // GF2BaseVector return a GF2Vector representing the base with index i.
-// Panic if i is out of range.
+// Panic if i is out of range, it is GF2BaseVectorErr panicking with its error.
 func (s *GF2VectorSpace) GF2BaseVector(i uint) *GF2Vector {
+	b, err := s.GF2BaseVectorErr(i)
+	if err != nil {
+		panic(err.Error())
+	}
+	return b
+}
+
+// GF2BaseVectorErr return a GF2Vector representing the base with index i.
+// Return an error wrapping ErrValueOutOfRange if i is out of range.
+func (s *GF2VectorSpace) GF2BaseVectorErr(i uint) (*GF2Vector, error) {
 	if i == 0 || s.dim < i {
-		panic(fmt.Sprintf("GF2BaseVector(i): i = %v out of range [1, %v]", i, s.dim))
+		return nil, outOfRange("GF2BaseVector(i)", "i = %v out of range [1, %v]", i, s.dim)
+	}
+	if s.isWide() {
+		b := s.GF2Zeros()
+		b.words[(i-1)/bits.UintSize] = uint(1) << ((i - 1) % bits.UintSize)
+		return b, nil
 	}
 	v := uint(1) << (i - 1)
-	b := GF2Vector{s, v}
-	return &b
+	b := GF2Vector{sp: s, val: v}
+	return &b, nil
 }
 
 // BaseVector return the base vector representing the base with index i in the same vector space.
@@ -125,48 +324,194 @@ func (v *GF2Vector) GF2BaseVector(i uint) *GF2Vector {
 
 // GF2Zeros return a GF2Vector where all bits are unset.
 func (s *GF2VectorSpace) GF2Zeros() *GF2Vector {
-	b := GF2Vector{s, 0}
+	b := GF2Vector{sp: s}
+	if s.isWide() {
+		b.words = make([]uint, s.words())
+	}
 	return &b
 }
 
 // GF2Ones return a GF2Vector where dim bits are set.
 func (s *GF2VectorSpace) GF2Ones() *GF2Vector {
-	b := GF2Vector{s, s.ones}
+	if s.isWide() {
+		b := GF2Vector{sp: s, words: make([]uint, s.words())}
+		n := len(b.words) - 1
+		for i := range n {
+			b.words[i] = ^uint(0)
+		}
+		b.words[n] = s.ones
+		return &b
+	}
+	b := GF2Vector{sp: s, val: s.ones}
 	return &b
 }
 
 // IsZeros return true if all bits are unset.
 func (v *GF2Vector) IsZeros() bool {
+	for _, w := range v.words {
+		if w != 0 {
+			return false
+		}
+	}
 	return v.val == 0
 }
 
 // IsOnes return true if all bits are set.
 func (v *GF2Vector) IsOnes() bool {
-	return v.val == v.sp.ones
+	if v.words == nil {
+		return v.val == v.sp.ones
+	}
+	n := len(v.words) - 1
+	for _, w := range v.words[:n] {
+		if w != ^uint(0) {
+			return false
+		}
+	}
+	return v.words[n] == v.sp.ones
 }
 
 // Index return the index of the coordinate of a base vector.
 // Use https://graphics.stanford.edu/~seander/bithacks.html#DetermineIfPowerOf2
 // Index is zero and isBase is false if v is no base vector.
 func (v *GF2Vector) Index() (index uint, isBase bool) {
-	c := v.val
-	if (c > 0) && (c&(c-1)) == 0 {
-		return uint(bits.Len(c)), true
+	if v.words == nil {
+		c := v.val
+		if (c > 0) && (c&(c-1)) == 0 {
+			return uint(bits.Len(c)), true
+		}
+		return
 	}
-	return
+	for i, c := range v.words {
+		if c == 0 {
+			continue
+		}
+		if index > 0 || (c&(c-1)) != 0 {
+			return 0, false
+		}
+		index = uint(i)*bits.UintSize + uint(bits.Len(c))
+	}
+	return index, index > 0
 }
 
 // IsBaseVector return true if v is a base vector.
 // Use https://graphics.stanford.edu/~seander/bithacks.html#DetermineIfPowerOf2
 func (v *GF2Vector) IsBaseVector() bool {
-	c := v.val
-	return (c > 0) && (c&(c-1)) == 0
+	if v.words == nil {
+		c := v.val
+		return (c > 0) && (c&(c-1)) == 0
+	}
+	_, isBase := v.Index()
+	return isBase
+}
+
+// checkIndex panic if i is not a valid index of a coordinate of v.
+func (v *GF2Vector) checkIndex(op string, i uint) {
+	if i == 0 || v.sp.dim < i {
+		panic(outOfRange(op, "i = %v out of range [1, %v]", i, v.sp.dim).Error())
+	}
+}
+
+// Bit return the value of the coordinate with index i, 0 or 1.
+// Index 1 is the coordinate of GF2BaseVector(1), the least significant bit.
+// Panic if i is out of range.
+func (v *GF2Vector) Bit(i uint) uint {
+	v.checkIndex("Bit(i)", i)
+	i--
+	if v.words == nil {
+		return (v.val >> i) & 1
+	}
+	return (v.words[i/bits.UintSize] >> (i % bits.UintSize)) & 1
+}
+
+// SetBit set the coordinate with index i of v to b, 0 or 1, and return v.
+// Panic if i or b is out of range.
+func (v *GF2Vector) SetBit(i uint, b uint) *GF2Vector {
+	v.checkIndex("SetBit(i, b)", i)
+	if b > 1 {
+		panic(outOfRange("SetBit(i, b)", "b = %v > 1", b).Error())
+	}
+	i--
+	m := uint(1) << (i % bits.UintSize)
+	w := &v.val
+	if v.words != nil {
+		w = &v.words[i/bits.UintSize]
+	}
+	if b == 0 {
+		*w &^= m
+	} else {
+		*w |= m
+	}
+	return v
+}
+
+// FlipBit toggle the coordinate with index i of v and return v.
+// Panic if i is out of range.
+func (v *GF2Vector) FlipBit(i uint) *GF2Vector {
+	v.checkIndex("FlipBit(i)", i)
+	i--
+	if v.words == nil {
+		v.val ^= uint(1) << i
+	} else {
+		v.words[i/bits.UintSize] ^= uint(1) << (i % bits.UintSize)
+	}
+	return v
+}
+
+// Ones return an iterator over the indices of the set coordinates of v,
+// in ascending order.
+func (v *GF2Vector) Ones() iter.Seq[uint] {
+	return func(yield func(uint) bool) {
+		v.ones(false, yield)
+	}
+}
+
+// Unset return an iterator over the indices of the unset coordinates of v,
+// in ascending order. It is not named Zeros like Ones, because the method
+// Zeros returning the zero vector exists already.
+func (v *GF2Vector) Unset() iter.Seq[uint] {
+	return func(yield func(uint) bool) {
+		v.ones(true, yield)
+	}
+}
+
+// ones yield the indices of the set coordinates of v, or of ^v if not is true.
+// Return false if yield returned false.
+func (v *GF2Vector) ones(not bool, yield func(uint) bool) bool {
+	ws := wordsOf(v)
+	last := len(ws) - 1
+	for k, w := range ws {
+		if not {
+			w = ^w
+			if k == last {
+				w &= v.sp.ones
+			}
+		}
+		base := uint(k) * bits.UintSize
+		for w != 0 {
+			if !yield(base + uint(bits.TrailingZeros(w)) + 1) {
+				return false
+			}
+			w &= w - 1
+		}
+	}
+	return true
+}
+
+// All return an iterator over the pairs of index and value of all
+// coordinates of v, in ascending order of the index.
+func (v *GF2Vector) All() iter.Seq2[uint, uint] {
+	return func(yield func(uint, uint) bool) {
+		for i := uint(1); i <= v.sp.dim; i++ {
+			if !yield(i, v.Bit(i)) {
+				return
+			}
+		}
+	}
 }
 
 // Zeros return the zero value of x, sharing the same vector space.
 func (x *GF2Vector) Zeros() *GF2Vector {
-	c := GF2Vector{x.sp, 0}
-	return &c
+	return x.sp.GF2Zeros()
 }
 
 // Copy return a copy of x, sharing the same vector space.
@@ -175,65 +520,123 @@ func (x *GF2Vector) Copy() *GF2Vector {
 	if x == nil {
 		return x
 	}
-	c := GF2Vector{x.sp, x.val}
+	c := GF2Vector{sp: x.sp, val: x.val}
+	if x.words != nil {
+		c.words = make([]uint, len(x.words))
+		copy(c.words, x.words)
+	}
 	return &c
 }
 
 // Not returns ^x, the negation of x.
 func Not(x *GF2Vector) *GF2Vector {
-	b := GF2Vector{x.sp, x.sp.ones ^ x.val}
-	return &b
+	return new(GF2Vector).Not(x)
 }
 
 // And return x_1 & x_2 & ...,
-// panic if x_i and x_j are of vector spaces of different dimension.
+// panic, if x_i and x_j are of vector spaces of different dimension.
+// It is AndErr panicking with its error.
 func And(x ...*GF2Vector) *GF2Vector {
-	n := len(x)
-	z := *x[0]
-	for i := 1; i < n; i++ {
-		y := x[i]
-		if z.sp.dim != y.sp.dim {
-			panic(fmt.Sprintf("And: incompatible vector spaces: "+
-				"z.dim = %v != %v = y.dim",
-				z.sp.dim, y.sp.dim))
-		}
+	z, err := AndErr(x...)
+	if err != nil {
+		panic(err.Error())
+	}
+	return z
+}
+
+// AndErr return x_1 & x_2 & ...,
+// return an error wrapping ErrDimensionMismatch, if x_i and x_j are of vector
+// spaces of different dimension, or wrapping ErrValueOutOfRange if x is empty.
+func AndErr(x ...*GF2Vector) (*GF2Vector, error) {
+	if err := checkSpaces("And", x); err != nil {
+		return nil, err
+	}
+	z := x[0].Copy()
+	for _, y := range x[1:] {
 		z.val &= y.val
+		for j, w := range y.words {
+			z.words[j] &= w
+		}
 	}
-	return &z
+	return z, nil
 }
 
 // Or return x_1 | x_2 | ...,
 // panic, if x_i and x_j are of vector spaces of different dimension.
+// It is OrErr panicking with its error.
 func Or(x ...*GF2Vector) *GF2Vector {
-	n := len(x)
-	z := *x[0]
-	for i := 1; i < n; i++ {
-		y := x[i]
-		if z.sp.dim != y.sp.dim {
-			panic(fmt.Sprintf("Or: incompatible vector spaces: "+
-				"z.dim = %v != %v = y.dim",
-				z.sp.dim, y.sp.dim))
-		}
+	z, err := OrErr(x...)
+	if err != nil {
+		panic(err.Error())
+	}
+	return z
+}
+
+// OrErr return x_1 | x_2 | ...,
+// return an error wrapping ErrDimensionMismatch, if x_i and x_j are of vector
+// spaces of different dimension, or wrapping ErrValueOutOfRange if x is empty.
+func OrErr(x ...*GF2Vector) (*GF2Vector, error) {
+	if err := checkSpaces("Or", x); err != nil {
+		return nil, err
+	}
+	z := x[0].Copy()
+	for _, y := range x[1:] {
 		z.val |= y.val
+		for j, w := range y.words {
+			z.words[j] |= w
+		}
 	}
-	return &z
+	return z, nil
 }
 
 // Xor return x_1 | x_2 | ...,
 // panic, if x_i and x_j are of vector spaces of different dimension.
+// It is XorErr panicking with its error.
 func Xor(x ...*GF2Vector) *GF2Vector {
-	n := len(x)
-	z := *x[0]
-	for i := 1; i < n; i++ {
-		y := x[i]
+	z, err := XorErr(x...)
+	if err != nil {
+		panic(err.Error())
+	}
+	return z
+}
+
+// XorErr return x_1 ^ x_2 ^ ...,
+// return an error wrapping ErrDimensionMismatch, if x_i and x_j are of vector
+// spaces of different dimension, or wrapping ErrValueOutOfRange if x is empty.
+func XorErr(x ...*GF2Vector) (*GF2Vector, error) {
+	if err := checkSpaces("Xor", x); err != nil {
+		return nil, err
+	}
+	z := x[0].Copy()
+	for _, y := range x[1:] {
+		z.val ^= y.val
+		for j, w := range y.words {
+			z.words[j] ^= w
+		}
+	}
+	return z, nil
+}
+
+// checkSpaces return an error if x is empty or the vectors x_i and x_j are
+// of vector spaces of different dimension.
+func checkSpaces(op string, x []*GF2Vector) error {
+	if len(x) == 0 {
+		return outOfRange(op, "no vector given")
+	}
+	z := x[0]
+	for _, y := range x[1:] {
 		if z.sp.dim != y.sp.dim {
-			panic(fmt.Sprintf("Xor: incompatible vector spaces: "+
-				"z.dim = %v != %v = y.dim",
-				z.sp.dim, y.sp.dim))
+			return mismatch(op, z.sp.dim, y.sp.dim)
 		}
-		z.val ^= y.val
 	}
-	return &z
+	return nil
+}
+
+// mismatch return a VectorSpaceError wrapping ErrDimensionMismatch.
+func mismatch(op string, zdim, ydim uint) error {
+	return &VectorSpaceError{op,
+		fmt.Sprintf("incompatible vector spaces: z.dim = %v != %v = y.dim", zdim, ydim),
+		ErrDimensionMismatch}
 }
 
 // ComplementOr return z = Not(Or(x) = ^(x_1 | x_2 | ...),
@@ -270,20 +673,169 @@ func ToggleBits(x, m *GF2Vector) *GF2Vector {
 	return Xor(x, m)
 }
 
+// The following methods set the receiver z to the result and return z,
+// in the style of math/big. The words of z are reused if possible,
+// so no allocation is needed if z is of the same vector space as the
+// operands. The operands may alias z. A zero value GF2Vector may be used
+// as receiver, it takes the vector space of the operands.
+// Panic if x and y are of vector spaces of different dimension.
+
+// reuse prepare z as vector of space sp without clearing its value,
+// as z may alias an operand.
+func (z *GF2Vector) reuse(sp *GF2VectorSpace) {
+	z.sp = sp
+	if !sp.isWide() {
+		z.words = nil
+		return
+	}
+	n := sp.words()
+	if cap(z.words) < n {
+		w := make([]uint, n)
+		copy(w, z.words)
+		z.words = w
+	}
+	z.words = z.words[:n]
+}
+
+// sameSpace panic if x and y are of vector spaces of different dimension.
+func sameSpace(op string, x, y *GF2Vector) {
+	if x.sp.dim != y.sp.dim {
+		panic(mismatch(op, x.sp.dim, y.sp.dim).Error())
+	}
+}
+
+// Set sets z = x and returns z.
+func (z *GF2Vector) Set(x *GF2Vector) *GF2Vector {
+	if z == x {
+		return z
+	}
+	z.reuse(x.sp)
+	z.val = x.val
+	copy(z.words, x.words)
+	return z
+}
+
+// Not sets z = ^x and returns z.
+func (z *GF2Vector) Not(x *GF2Vector) *GF2Vector {
+	z.reuse(x.sp)
+	z.val = x.sp.ones ^ x.val
+	if n := len(x.words) - 1; n >= 0 {
+		for i, w := range x.words[:n] {
+			z.words[i] = ^w
+		}
+		z.words[n] = x.sp.ones ^ x.words[n]
+		z.val = 0
+	}
+	return z
+}
+
+// And sets z = x & y and returns z.
+func (z *GF2Vector) And(x, y *GF2Vector) *GF2Vector {
+	sameSpace("And", x, y)
+	z.reuse(x.sp)
+	z.val = x.val & y.val
+	for i, w := range x.words {
+		z.words[i] = w & y.words[i]
+	}
+	return z
+}
+
+// AndNot sets z = x &^ y and returns z.
+func (z *GF2Vector) AndNot(x, y *GF2Vector) *GF2Vector {
+	sameSpace("AndNot", x, y)
+	z.reuse(x.sp)
+	z.val = x.val &^ y.val
+	for i, w := range x.words {
+		z.words[i] = w &^ y.words[i]
+	}
+	return z
+}
+
+// Or sets z = x | y and returns z.
+func (z *GF2Vector) Or(x, y *GF2Vector) *GF2Vector {
+	sameSpace("Or", x, y)
+	z.reuse(x.sp)
+	z.val = x.val | y.val
+	for i, w := range x.words {
+		z.words[i] = w | y.words[i]
+	}
+	return z
+}
+
+// Xor sets z = x ^ y and returns z.
+func (z *GF2Vector) Xor(x, y *GF2Vector) *GF2Vector {
+	sameSpace("Xor", x, y)
+	z.reuse(x.sp)
+	z.val = x.val ^ y.val
+	for i, w := range x.words {
+		z.words[i] = w ^ y.words[i]
+	}
+	return z
+}
+
+// ComplementOr sets z = ^(x | y) and returns z.
+func (z *GF2Vector) ComplementOr(x, y *GF2Vector) *GF2Vector {
+	return z.Not(z.Or(x, y))
+}
+
+// ComplementXor sets z = ^(x ^ y) and returns z.
+func (z *GF2Vector) ComplementXor(x, y *GF2Vector) *GF2Vector {
+	return z.Not(z.Xor(x, y))
+}
+
+// MaskBits sets z = x & m and returns z.
+func (z *GF2Vector) MaskBits(x, m *GF2Vector) *GF2Vector {
+	return z.And(x, m)
+}
+
+// ClearBits sets z = x & ^m and returns z.
+func (z *GF2Vector) ClearBits(x, m *GF2Vector) *GF2Vector {
+	return z.AndNot(x, m)
+}
+
+// SetBits sets z = x | m and returns z.
+func (z *GF2Vector) SetBits(x, m *GF2Vector) *GF2Vector {
+	return z.Or(x, m)
+}
+
+// ToggleBits sets z = x ^ m and returns z.
+func (z *GF2Vector) ToggleBits(x, m *GF2Vector) *GF2Vector {
+	return z.Xor(x, m)
+}
+
 // OnesCount returns the number of one bits ("population count") in x.
 func OnesCount(x *GF2Vector) int {
-	return bits.OnesCount(x.val)
+	c := bits.OnesCount(x.val)
+	for _, w := range x.words {
+		c += bits.OnesCount(w)
+	}
+	return c
 }
 
 // ScalarProduct returns the scalar product of 2 vectors, which is the norm, the OnesCount of the product vector.
+// The inner product of GF(2) is this value mod 2, see Dot.
 func ScalarProduct(a, b *GF2Vector) int {
 	prod := And(a, b)
 	return OnesCount(prod)
 }
 
+// Dot returns the inner product of GF(2) of 2 vectors, 0 or 1,
+// the parity of the OnesCount of a & b.
+// Panic if a and b are of vector spaces of different dimension.
+func Dot(a, b *GF2Vector) uint {
+	sameSpace("Dot", a, b)
+	p := a.val & b.val
+	for i, w := range a.words {
+		p ^= w & b.words[i]
+	}
+	return uint(bits.OnesCount(p) & 1)
+}
+
 // SpanOfSubspace returns true and the subspace of a set of vectors s if the
 // vectors span a subspace. For true the dimension of the set s must be
 // greater or equal the Norm of the union of the set.
+// Only coordinate sub vector spaces are found, for the span of any set
+// of vectors use SpanOf.
 // Steinitz exchange lemma:
 // https://en.wikipedia.org/w/index.php?title=Steinitz_exchange_lemma&oldid=1336271854
 // The dimension of each span of a vector space is greater or equal to the
@@ -293,7 +845,7 @@ func SpanOfSubspace(s []*GF2Vector) (Ok bool, sp *GF2SubVectorSpace) {
 	dim := OnesCount(span)
 	if dim <= len(s) {
 		// we have a subspace
-		svs := GF2SubVectorSpace{(*s[0].sp), span.val}
+		svs := GF2SubVectorSpace{(*s[0].sp), span.val, span.words}
 		return true, &svs
 	}
 	return
@@ -5,8 +5,12 @@
 package gf2vs
 
 import (
+	"errors"
 	"fmt"
+	"math"
 	"math/bits"
+	"slices"
+	"strings"
 	"testing"
 )
 
@@ -26,7 +30,6 @@ func TestNewGF2VectorSpaceString(t *testing.T) {
 		want string
 	}{
 		{0, "NewGF2VectorSpace(dim): dim = 0 < 1"},
-		{bits.UintSize + 1, "NewGF2VectorSpace(dim): dim = 65 > 64 = bits.UintSize"},
 	}
 	for _, c := range cases {
 		func(in uint, want string) {
@@ -54,6 +57,11 @@ func TestNewGF2VectorSpaceString(t *testing.T) {
 		{2, "GF(2)sp{2: 3}"},
 		{3, "GF(2)sp{3: 7}"},
 		{4, "GF(2)sp{4: 15}"},
+		// ones of a wide vector space are the ones of the last word
+		{bits.UintSize, fmt.Sprintf("GF(2)sp{%v: %v}", bits.UintSize, uint(math.MaxUint))},
+		{bits.UintSize + 1, fmt.Sprintf("GF(2)sp{%v: 1}", bits.UintSize+1)},
+		{2*bits.UintSize + 3, fmt.Sprintf("GF(2)sp{%v: 7}", 2*bits.UintSize+3)},
+		{10000, fmt.Sprintf("GF(2)sp{10000: %v}", uint(1)<<(10000%bits.UintSize)-1)},
 	}
 	for _, c := range cases {
 		sp := NewGF2VectorSpace(c.in)
@@ -73,12 +81,11 @@ func TestNewGF2SubVectorSpaceString(t *testing.T) {
 	}{
 		// panic from NewGF2VectorSpace
 		{0, 0, "NewGF2VectorSpace(dim): dim = 0 < 1"},
-		{bits.UintSize + 1, 2, "NewGF2VectorSpace(dim): dim = 65 > 64 = bits.UintSize"},
 		// panic from NewGF2SubVectorSpace
 		{1, 2, "NewGF2SubVectorSpace(dim): base 2 not in space with dim = 1"},
 		{3, 5, "NewGF2SubVectorSpace(dim): base 5 not in space with dim = 3"},
 	}
-	for _, c := range cases[0:2] {
+	for _, c := range cases[0:1] {
 		func(in, b uint, want string) {
 			defer func(in uint, want string) {
 				r := recover()
@@ -95,7 +102,7 @@ func TestNewGF2SubVectorSpaceString(t *testing.T) {
 			NewGF2SubVectorSpace(c.n, c.b)
 		}(c.n, c.b, c.want)
 	}
-	for _, c := range cases[2:] {
+	for _, c := range cases[1:] {
 		func(in, b uint, want string) {
 			defer func(in, b uint, want string) {
 				r := recover()
@@ -122,6 +129,8 @@ func TestNewGF2SubVectorSpaceString(t *testing.T) {
 		{2, 1, "GF(2)ssp{2: 3, 1}"},
 		{3, 2, "GF(2)ssp{3: 7, 2}"},
 		{4, 3, "GF(2)ssp{4: 15, 3}"},
+		{bits.UintSize + 2, 3, fmt.Sprintf("GF(2)ssp{%v: 3, %v11}",
+			bits.UintSize+2, strings.Repeat("0", bits.UintSize))},
 	}
 	for _, c := range cases {
 		sp := NewGF2SubVectorSpace(c.n, c.b)
@@ -134,7 +143,7 @@ func TestNewGF2SubVectorSpaceString(t *testing.T) {
 
 func TestGF2Vector(t *testing.T) {
 	s := NewGF2VectorSpace(3)
-	v := GF2Vector{s, 2}
+	v := GF2Vector{sp: s, val: 2}
 	want := "010"
 	wVal2 := uint(2)
 	wVal0 := uint(0)
@@ -152,6 +161,16 @@ func TestGF2Vector(t *testing.T) {
 	if gVal != wVal0 {
 		t.Errorf("nil.Val() = %v, want %v", gVal, wVal0)
 	}
+	if gVal, err := v.ValErr(); gVal != wVal2 || err != nil {
+		t.Errorf("GF2Vector{3, 2}.ValErr() = %v, %v, want %v", gVal, err, wVal2)
+	}
+	w := NewGF2VectorSpace(bits.UintSize + 1).NewGF2VectorWords([]uint{2, 1})
+	if gVal, err := w.ValErr(); !errors.Is(err, ErrValueOutOfRange) {
+		t.Errorf("%v.ValErr() = %v, %v, want %v", w, gVal, err, ErrValueOutOfRange)
+	}
+	if gVal := w.Val(); gVal != wVal2 {
+		t.Errorf("%v.Val() = %v, want the truncated %v", w, gVal, wVal2)
+	}
 }
 
 // TestNewGF2VectorString test NewGF2Vector and String
@@ -1587,3 +1606,424 @@ func TestSpanOfSupspace(t *testing.T) {
 		}
 	}
 }
+
+// TestWideGF2Vector test the vectors of spaces with more than bits.UintSize dimensions.
+func TestWideGF2Vector(t *testing.T) {
+	cases := []struct {
+		dim   uint
+		words []uint
+		ones  int
+		index uint
+		isB   bool
+	}{
+		{bits.UintSize + 1, []uint{0, 0}, 0, 0, false},
+		{bits.UintSize + 1, []uint{1, 0}, 1, 1, true},
+		{bits.UintSize + 1, []uint{0, 1}, 1, bits.UintSize + 1, true},
+		{bits.UintSize + 1, []uint{1, 1}, 2, 0, false},
+		{bits.UintSize + 1, []uint{^uint(0), 1}, bits.UintSize + 1, 0, false},
+		{2*bits.UintSize + 2, []uint{0, 1 << (bits.UintSize - 1)}, 1, 2 * bits.UintSize, true},
+		{2*bits.UintSize + 2, []uint{0, 0, 2}, 1, 2*bits.UintSize + 2, true},
+		{10000, []uint{0, 0, 3}, 2, 0, false},
+	}
+	for _, c := range cases {
+		sp := NewGF2VectorSpace(c.dim)
+		v := sp.NewGF2VectorWords(c.words)
+		vs := v.String()
+		if uint(len(vs)) != c.dim {
+			t.Errorf("len(%v.String()) = %v, want %v", c.words, len(vs), c.dim)
+		}
+		if got := OnesCount(v); got != c.ones {
+			t.Errorf("OnesCount(%v) = %v, want %v", c.words, got, c.ones)
+		}
+		if got := strings.Count(vs, "1"); got != c.ones {
+			t.Errorf("%v.String() has %v ones, want %v", c.words, got, c.ones)
+		}
+		index, isB := v.Index()
+		if index != c.index || isB != c.isB || v.IsBaseVector() != c.isB {
+			t.Errorf("%v.Index() = %v, %v, want %v, %v",
+				c.words, index, isB, c.index, c.isB)
+		}
+		if c.isB {
+			b := sp.GF2BaseVector(c.index)
+			if fmt.Sprint(b) != vs {
+				t.Errorf("GF2BaseVector(%v) = %v, want %v", c.index, b, vs)
+			}
+		}
+		if got := v.IsZeros(); got != (c.ones == 0) {
+			t.Errorf("%v.IsZeros() = %v, want %v", c.words, got, c.ones == 0)
+		}
+		n := Not(v)
+		if got := OnesCount(n); got != int(c.dim)-c.ones {
+			t.Errorf("OnesCount(Not(%v)) = %v, want %v", c.words, got, int(c.dim)-c.ones)
+		}
+		if !Or(v, n).IsOnes() || !Xor(v, n).IsOnes() || !And(v, n).IsZeros() {
+			t.Errorf("%v and Not(%v) are not complementary", c.words, c.words)
+		}
+		if got := ScalarProduct(v, sp.GF2Ones()); got != c.ones {
+			t.Errorf("ScalarProduct(%v, Ones) = %v, want %v", c.words, got, c.ones)
+		}
+		// make sure change of copy is not affecting source
+		cp := v.Copy()
+		cp.words[0]++
+		if got := v.String(); got != vs {
+			t.Errorf("%v.Copy() copy changed to %v", vs, got)
+		}
+		// make sure operations are not affecting the operands
+		Xor(v, sp.GF2Ones())
+		if got := v.String(); got != vs {
+			t.Errorf("Xor(%v, Ones) changed operand to %v", vs, got)
+		}
+		if got := v.Words(); fmt.Sprint(got[:len(c.words)]) != fmt.Sprint(c.words) {
+			t.Errorf("%v.Words() = %v", c.words, got)
+		}
+	}
+}
+
+func TestWideSpanOfSupspace(t *testing.T) {
+	sp := NewGF2VectorSpace(200)
+	s := []*GF2Vector{sp.GF2BaseVector(1), sp.GF2BaseVector(100), sp.GF2BaseVector(200)}
+	ok, got := SpanOfSubspace(s)
+	if !ok || OnesCount(&GF2Vector{sp: sp, words: got.subWords}) != 3 {
+		t.Errorf("SpanOfSubspace(%v) = %v, %v, want true", s, ok, got)
+	}
+	s = []*GF2Vector{Xor(s...)}
+	ok, _ = SpanOfSubspace(s)
+	if ok {
+		t.Errorf("SpanOfSubspace(%v) = %v, want false", s, ok)
+	}
+}
+
+func TestNewGF2VectorWords(t *testing.T) {
+	cases := []struct {
+		dim   uint
+		words []uint
+		want  string
+	}{
+		{3, []uint{8}, "NewGF2VectorWords(w): w[0] = 8 > 7"},
+		{3, []uint{1, 0}, "NewGF2VectorWords(w): len(w) = 2 > 1"},
+		{bits.UintSize + 1, []uint{1, 2}, "NewGF2VectorWords(w): w[1] = 2 > 1"},
+	}
+	for _, c := range cases {
+		func(dim uint, words []uint, want string) {
+			defer func() {
+				r := recover()
+				if r != want {
+					t.Errorf("%v.NewGF2VectorWords(%v) == Panic(%v), want Panic(%v)",
+						dim, words, r, want)
+				}
+			}()
+			NewGF2VectorSpace(dim).NewGF2VectorWords(words)
+		}(c.dim, c.words, c.want)
+	}
+	v := NewGF2VectorSpace(3).NewGF2VectorWords([]uint{5})
+	if got := fmt.Sprint(v); got != "101" {
+		t.Errorf("3.NewGF2VectorWords([5]) = %v, want 101", got)
+	}
+}
+
+// TestErrVariants test the error returning variants of the panicking functions.
+func TestErrVariants(t *testing.T) {
+	sp1 := NewGF2VectorSpace(1)
+	sp2 := NewGF2VectorSpace(2)
+	cases := []struct {
+		name   string
+		call   func() (any, error)
+		target error
+		want   string
+	}{
+		{"NewGF2VectorSpaceErr(0)",
+			func() (any, error) { return NewGF2VectorSpaceErr(0) },
+			ErrValueOutOfRange, "NewGF2VectorSpace(dim): dim = 0 < 1"},
+		{"NewGF2SubVectorSpaceErr(1, 2)",
+			func() (any, error) { return NewGF2SubVectorSpaceErr(1, 2) },
+			ErrValueOutOfRange, "NewGF2SubVectorSpace(dim): base 2 not in space with dim = 1"},
+		{"NewGF2SubVectorSpaceErr(0, 0)",
+			func() (any, error) { return NewGF2SubVectorSpaceErr(0, 0) },
+			ErrValueOutOfRange, "NewGF2VectorSpace(dim): dim = 0 < 1"},
+		{"NewGF2VectorErr(4)",
+			func() (any, error) { return sp2.NewGF2VectorErr(4) },
+			ErrValueOutOfRange, "NewGF2Vector(value): value = 4 > 3"},
+		{"NewGF2VectorWordsErr([4])",
+			func() (any, error) { return sp2.NewGF2VectorWordsErr([]uint{4}) },
+			ErrValueOutOfRange, "NewGF2VectorWords(w): w[0] = 4 > 3"},
+		{"GF2BaseVectorErr(3)",
+			func() (any, error) { return sp2.GF2BaseVectorErr(3) },
+			ErrValueOutOfRange, "GF2BaseVector(i): i = 3 out of range [1, 2]"},
+		{"AndErr()",
+			func() (any, error) { return AndErr() },
+			ErrValueOutOfRange, "And: no vector given"},
+		{"AndErr(1, 2)",
+			func() (any, error) { return AndErr(sp1.GF2Ones(), sp2.GF2Ones()) },
+			ErrDimensionMismatch, "And: incompatible vector spaces: z.dim = 1 != 2 = y.dim"},
+		{"OrErr(1, 2)",
+			func() (any, error) { return OrErr(sp1.GF2Ones(), sp2.GF2Ones()) },
+			ErrDimensionMismatch, "Or: incompatible vector spaces: z.dim = 1 != 2 = y.dim"},
+		{"XorErr(2, 1, 2)",
+			func() (any, error) { return XorErr(sp2.GF2Ones(), sp1.GF2Ones(), sp2.GF2Ones()) },
+			ErrDimensionMismatch, "Xor: incompatible vector spaces: z.dim = 2 != 1 = y.dim"},
+	}
+	for _, c := range cases {
+		_, err := c.call()
+		if !errors.Is(err, c.target) {
+			t.Errorf("%v = %v, want errors.Is %v", c.name, err, c.target)
+		}
+		var vsErr *VectorSpaceError
+		if !errors.As(err, &vsErr) {
+			t.Errorf("%v = %T, want *VectorSpaceError", c.name, err)
+		} else if vsErr.Error() != c.want {
+			t.Errorf("%v = %v, want %v", c.name, vsErr, c.want)
+		}
+	}
+
+	// valid input returns no error
+	sp, err := NewGF2VectorSpaceErr(3)
+	if err != nil {
+		t.Fatalf("NewGF2VectorSpaceErr(3) = %v", err)
+	}
+	v, err := sp.NewGF2VectorErr(5)
+	if err != nil || v.String() != "101" {
+		t.Errorf("NewGF2VectorErr(5) = %v, %v, want 101, nil", v, err)
+	}
+	b, err := sp.GF2BaseVectorErr(2)
+	if err != nil || b.String() != "010" {
+		t.Errorf("GF2BaseVectorErr(2) = %v, %v, want 010, nil", b, err)
+	}
+	z, err := XorErr(v, b)
+	if err != nil || z.String() != "111" {
+		t.Errorf("XorErr(%v, %v) = %v, %v, want 111, nil", v, b, z, err)
+	}
+}
+
+// TestInPlace test the methods setting the receiver against the functions.
+func TestInPlace(t *testing.T) {
+	type op struct {
+		name string
+		fn   func(x, y *GF2Vector) *GF2Vector
+		m    func(z, x, y *GF2Vector) *GF2Vector
+	}
+	ops := []op{
+		{"And", func(x, y *GF2Vector) *GF2Vector { return And(x, y) },
+			(*GF2Vector).And},
+		{"AndNot", ClearBits, (*GF2Vector).AndNot},
+		{"Or", func(x, y *GF2Vector) *GF2Vector { return Or(x, y) },
+			(*GF2Vector).Or},
+		{"Xor", func(x, y *GF2Vector) *GF2Vector { return Xor(x, y) },
+			(*GF2Vector).Xor},
+		{"ComplementOr", func(x, y *GF2Vector) *GF2Vector { return ComplementOr(x, y) },
+			(*GF2Vector).ComplementOr},
+		{"ComplementXor", func(x, y *GF2Vector) *GF2Vector { return ComplementXor(x, y) },
+			(*GF2Vector).ComplementXor},
+		{"MaskBits", MaskBits, (*GF2Vector).MaskBits},
+		{"ClearBits", ClearBits, (*GF2Vector).ClearBits},
+		{"SetBits", SetBits, (*GF2Vector).SetBits},
+		{"ToggleBits", ToggleBits, (*GF2Vector).ToggleBits},
+		{"Not", func(x, y *GF2Vector) *GF2Vector { return Not(x) },
+			func(z, x, y *GF2Vector) *GF2Vector { return z.Not(x) }},
+		{"Set", func(x, y *GF2Vector) *GF2Vector { return x.Copy() },
+			func(z, x, y *GF2Vector) *GF2Vector { return z.Set(x) }},
+	}
+	cases := []struct {
+		dim uint
+		x   []uint
+		y   []uint
+	}{
+		{4, []uint{0b1011}, []uint{0b1110}},
+		{64, []uint{0xF0F0}, []uint{^uint(0) >> 1}},
+		{130, []uint{0xF0F0, 5, 1}, []uint{3, ^uint(0), 3}},
+	}
+	for _, c := range cases {
+		sp := NewGF2VectorSpace(c.dim)
+		for _, o := range ops {
+			x := sp.NewGF2VectorWords(c.x)
+			y := sp.NewGF2VectorWords(c.y)
+			want := o.fn(x, y).String()
+			// zero value receiver
+			var z GF2Vector
+			if got := o.m(&z, x, y).String(); got != want {
+				t.Errorf("z.%v(%v, %v) = %v, want %v", o.name, x, y, got, want)
+			}
+			// reused receiver without allocation
+			allocs := testing.AllocsPerRun(10, func() { o.m(&z, x, y) })
+			if allocs != 0 {
+				t.Errorf("z.%v(%v, %v) allocates %v times", o.name, x, y, allocs)
+			}
+			// z aliasing x
+			if got := o.m(x, x, y).String(); got != want {
+				t.Errorf("x.%v(x, %v) = %v, want %v", o.name, y, got, want)
+			}
+			// z aliasing y
+			x = sp.NewGF2VectorWords(c.x)
+			if got := o.m(y, x, y).String(); got != want {
+				t.Errorf("y.%v(%v, y) = %v, want %v", o.name, x, got, want)
+			}
+			// x, y and z are the same vector
+			x = sp.NewGF2VectorWords(c.x)
+			want = o.fn(x, x).String()
+			if got := o.m(x, x, x).String(); got != want {
+				t.Errorf("x.%v(x, x) = %v, want %v", o.name, got, want)
+			}
+		}
+	}
+
+	// receiver of other space takes the space of the operands
+	z := NewGF2VectorSpace(200).GF2Ones()
+	sp := NewGF2VectorSpace(3)
+	z.Xor(sp.NewGF2Vector(5), sp.NewGF2Vector(3))
+	if got := z.String(); got != "110" {
+		t.Errorf("z.Xor(101, 011) = %v, want 110", got)
+	}
+	want := "Xor: incompatible vector spaces: z.dim = 3 != 200 = y.dim"
+	func() {
+		defer func() {
+			if r := recover(); r != want {
+				t.Errorf("z.Xor(3, 200) == Panic(%v), want Panic(%v)", r, want)
+			}
+		}()
+		z.Xor(z, NewGF2VectorSpace(200).GF2Ones())
+	}()
+}
+
+func TestBitSetBitFlipBit(t *testing.T) {
+	cases := []struct {
+		dim   uint
+		words []uint
+		i     uint
+		bit   uint
+		set   string
+		clear string
+	}{
+		{4, []uint{0b1010}, 1, 0, "1011", "1010"},
+		{4, []uint{0b1010}, 2, 1, "1010", "1000"},
+		{4, []uint{0b1010}, 4, 1, "1010", "0010"},
+		{bits.UintSize + 2, []uint{0, 0b10}, bits.UintSize + 2, 1,
+			"10" + strings.Repeat("0", bits.UintSize), strings.Repeat("0", bits.UintSize+2)},
+		{bits.UintSize + 2, []uint{0, 0b10}, bits.UintSize, 0,
+			"101" + strings.Repeat("0", bits.UintSize-1), "10" + strings.Repeat("0", bits.UintSize)},
+	}
+	for _, c := range cases {
+		sp := NewGF2VectorSpace(c.dim)
+		v := sp.NewGF2VectorWords(c.words)
+		vs := v.String()
+		if got := v.Bit(c.i); got != c.bit {
+			t.Errorf("%v.Bit(%v) = %v, want %v", vs, c.i, got, c.bit)
+		}
+		if got := v.Copy().SetBit(c.i, 1).String(); got != c.set {
+			t.Errorf("%v.SetBit(%v, 1) = %v, want %v", vs, c.i, got, c.set)
+		}
+		if got := v.Copy().SetBit(c.i, 0).String(); got != c.clear {
+			t.Errorf("%v.SetBit(%v, 0) = %v, want %v", vs, c.i, got, c.clear)
+		}
+		want := c.set
+		if c.bit == 1 {
+			want = c.clear
+		}
+		if got := v.FlipBit(c.i).String(); got != want {
+			t.Errorf("%v.FlipBit(%v) = %v, want %v", vs, c.i, got, want)
+		}
+	}
+
+	v := NewGF2VectorSpace(3).GF2Zeros()
+	panics := []struct {
+		call func()
+		want string
+	}{
+		{func() { v.Bit(0) }, "Bit(i): i = 0 out of range [1, 3]"},
+		{func() { v.SetBit(4, 1) }, "SetBit(i, b): i = 4 out of range [1, 3]"},
+		{func() { v.SetBit(1, 2) }, "SetBit(i, b): b = 2 > 1"},
+		{func() { v.FlipBit(4) }, "FlipBit(i): i = 4 out of range [1, 3]"},
+	}
+	for _, p := range panics {
+		func() {
+			defer func() {
+				if r := recover(); r != p.want {
+					t.Errorf("Panic(%v), want Panic(%v)", r, p.want)
+				}
+			}()
+			p.call()
+		}()
+	}
+}
+
+func TestOnesUnsetAll(t *testing.T) {
+	cases := []struct {
+		dim   uint
+		words []uint
+		ones  []uint
+	}{
+		{1, []uint{0}, nil},
+		{1, []uint{1}, []uint{1}},
+		{4, []uint{0b1010}, []uint{2, 4}},
+		{bits.UintSize, []uint{1<<(bits.UintSize-1) | 1}, []uint{1, bits.UintSize}},
+		{2*bits.UintSize + 2, []uint{1, 1 << (bits.UintSize - 1), 2}, []uint{1, 2 * bits.UintSize, 2*bits.UintSize + 2}},
+	}
+	for _, c := range cases {
+		sp := NewGF2VectorSpace(c.dim)
+		v := sp.NewGF2VectorWords(c.words)
+		got := slices.Collect(v.Ones())
+		if !slices.Equal(got, c.ones) {
+			t.Errorf("%v.Ones() = %v, want %v", v, got, c.ones)
+		}
+		var unset []uint
+		for i := uint(1); i <= c.dim; i++ {
+			if !slices.Contains(c.ones, i) {
+				unset = append(unset, i)
+			}
+		}
+		got = slices.Collect(v.Unset())
+		if !slices.Equal(got, unset) {
+			t.Errorf("%v.Unset() = %v, want %v", v, got, unset)
+		}
+		n := uint(0)
+		for i, b := range v.All() {
+			n++
+			if i != n || b != v.Bit(i) {
+				t.Errorf("%v.All() yield %v, %v, want %v, %v", v, i, b, n, v.Bit(i))
+			}
+		}
+		if n != c.dim {
+			t.Errorf("%v.All() yield %v pairs, want %v", v, n, c.dim)
+		}
+	}
+
+	// stop the iteration early
+	v := NewGF2VectorSpace(8).GF2Ones()
+	for i := range v.Ones() {
+		if i == 3 {
+			break
+		}
+	}
+	for i := range v.All() {
+		if i == 3 {
+			break
+		}
+	}
+}
+
+func TestDot(t *testing.T) {
+	cases := []struct {
+		dim  uint
+		a    []uint
+		b    []uint
+		want uint
+	}{
+		{1, []uint{0}, []uint{1}, 0},
+		{1, []uint{1}, []uint{1}, 1},
+		{3, []uint{0b011}, []uint{0b110}, 1},
+		{3, []uint{0b011}, []uint{0b111}, 0},
+		{3, []uint{0b111}, []uint{0b111}, 1},
+		{130, []uint{1, 1, 1}, []uint{1, 1, 0}, 0},
+		{130, []uint{1, 1, 3}, []uint{1, 0, 2}, 0},
+		{130, []uint{1, 1, 3}, []uint{0, 0, 2}, 1},
+	}
+	for _, c := range cases {
+		sp := NewGF2VectorSpace(c.dim)
+		a := sp.NewGF2VectorWords(c.a)
+		b := sp.NewGF2VectorWords(c.b)
+		if got := Dot(a, b); got != c.want {
+			t.Errorf("Dot(%v, %v) = %v, want %v", c.a, c.b, got, c.want)
+		}
+		if got := uint(ScalarProduct(a, b) % 2); got != c.want {
+			t.Errorf("ScalarProduct(%v, %v) %% 2 = %v, want %v", c.a, c.b, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,129 @@
+// Ralf Poeppel, 2026
+//
+// This file implements the Hamming metric of a vector space:
+// the distance of vectors, weight distributions and the enumeration of
+// Hamming balls and spheres. The enumerators reuse the yielded vector as
+// the iterators of enumerate.go, use Copy to keep it.
+
+package gf2vs
+
+import (
+	"iter"
+	"math"
+	"math/bits"
+)
+
+// Distance return the Hamming distance of a and b,
+// the count of coordinates in which they differ, OnesCount(Xor(a, b)).
+// Panic if a and b are of vector spaces of different dimension.
+func Distance(a, b *GF2Vector) int {
+	sameSpace("Distance", a, b)
+	d := bits.OnesCount(a.val ^ b.val)
+	for i, w := range a.words {
+		d += bits.OnesCount(w ^ b.words[i])
+	}
+	return d
+}
+
+// WeightDistribution return the weight distribution of the vectors s,
+// element i is the count of vectors with OnesCount i, i in [0, dim].
+// Return nil if s is empty.
+// Panic if x_i and x_j are of vector spaces of different dimension.
+func WeightDistribution(s []*GF2Vector) []int {
+	if len(s) == 0 {
+		return nil
+	}
+	mustSameSpace("WeightDistribution", s)
+	wd := make([]int, s[0].sp.dim+1)
+	for _, v := range s {
+		wd[OnesCount(v)]++
+	}
+	return wd
+}
+
+// maxWeightDistributionDim the largest dimension of a span, whose vectors
+// are enumerated by WeightDistribution.
+const maxWeightDistributionDim = 32
+
+// WeightDistribution return the weight distribution of span, element i is
+// the count of vectors of span with OnesCount i, i in [0, dim].
+// The 2**Dim() vectors are enumerated in Gray code order of the basis,
+// so the computation is feasible for small dimensions of span only.
+// Panic if Dim() > 32.
+func (span *GF2Span) WeightDistribution() []uint64 {
+	if span.Dim() > maxWeightDistributionDim {
+		panic(outOfRange("WeightDistribution()", "Dim() = %v > %v",
+			span.Dim(), maxWeightDistributionDim).Error())
+	}
+	wd := make([]uint64, span.sp.dim+1)
+	v := span.sp.GF2Zeros()
+	wd[0] = 1
+	for i := uint64(1); i>>span.Dim() == 0; i++ {
+		v.Xor(v, span.basis[bits.TrailingZeros64(i)])
+		wd[OnesCount(v)]++
+	}
+	return wd
+}
+
+// binomial return the binomial coefficient n over k,
+// math.MaxUint64 if it overflows.
+func binomial(n, k uint) uint64 {
+	if k > n {
+		return 0
+	}
+	k = min(k, n-k)
+	b := uint64(1)
+	for i := uint64(1); i <= uint64(k); i++ {
+		hi, lo := bits.Mul64(b, uint64(n-k)+i)
+		if hi >= i {
+			return math.MaxUint64
+		}
+		b, _ = bits.Div64(hi, lo, i)
+	}
+	return b
+}
+
+// BallSize return the count of vectors of s within Hamming distance r of
+// any vector, the sum of the binomial coefficients dim over i for i in [0, r].
+// The size saturates at math.MaxUint64.
+func (s *GF2VectorSpace) BallSize(r uint) uint64 {
+	var n uint64
+	for i := range min(r, s.dim) + 1 {
+		c, carry := bits.Add64(n, binomial(s.dim, i), 0)
+		if carry != 0 {
+			return math.MaxUint64
+		}
+		n = c
+	}
+	return n
+}
+
+// Sphere return an iterator over all vectors with Hamming distance r of the
+// center c, in the order of ConstantWeight(r) of the differences.
+func (c *GF2Vector) Sphere(r uint) iter.Seq[*GF2Vector] {
+	return func(yield func(*GF2Vector) bool) {
+		c.sphere(r, c.sp.GF2Zeros(), c.sp.GF2Zeros(), yield)
+	}
+}
+
+// sphere yield the vectors with Hamming distance r of c in z, e is used
+// for the differences. Return false if yield returned false.
+func (c *GF2Vector) sphere(r uint, z, e *GF2Vector, yield func(*GF2Vector) bool) bool {
+	return c.sp.constantWeight(r, e, func(e *GF2Vector) bool {
+		return yield(z.Xor(c, e))
+	})
+}
+
+// Ball return an iterator over all vectors within Hamming distance r of the
+// center c, ordered by distance ascending, starting with c.
+// The count of vectors is BallSize(r).
+func (c *GF2Vector) Ball(r uint) iter.Seq[*GF2Vector] {
+	return func(yield func(*GF2Vector) bool) {
+		z, e := c.sp.GF2Zeros(), c.sp.GF2Zeros()
+		for d := range min(r, c.sp.dim) + 1 {
+			if !c.sphere(d, z, e, yield) {
+				return
+			}
+		}
+	}
+}
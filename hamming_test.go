@@ -0,0 +1,167 @@
+// Ralf Poeppel, 2026
+
+package gf2vs
+
+import (
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"testing"
+)
+
+func TestDistance(t *testing.T) {
+	s := NewGF2VectorSpace(8)
+	w := NewGF2VectorSpace(130)
+	cases := []struct {
+		a, b *GF2Vector
+		want int
+	}{
+		{s.NewGF2Vector(0), s.NewGF2Vector(0), 0},
+		{s.NewGF2Vector(0b1010), s.NewGF2Vector(0b0110), 2},
+		{s.GF2Zeros(), s.GF2Ones(), 8},
+		{w.GF2Zeros(), w.GF2Ones(), 130},
+		{w.NewGF2VectorWords([]uint{1, 3, 2}), w.NewGF2VectorWords([]uint{0, 1, 3}), 3},
+	}
+	for _, c := range cases {
+		if got := Distance(c.a, c.b); got != c.want {
+			t.Errorf("Distance(%v, %v) = %v, want %v", c.a, c.b, got, c.want)
+		}
+	}
+	defer func() {
+		want := "Distance: incompatible vector spaces: z.dim = 8 != 130 = y.dim"
+		if r := recover(); r != want {
+			t.Errorf("Distance panic %v, want %v", r, want)
+		}
+	}()
+	Distance(s.GF2Zeros(), w.GF2Zeros())
+}
+
+func TestWeightDistribution(t *testing.T) {
+	s := NewGF2VectorSpace(4)
+	cases := []struct {
+		s    []*GF2Vector
+		want string
+	}{
+		{nil, "[]"},
+		{vectors(s, 0), "[1 0 0 0 0]"},
+		{vectors(s, 1, 2, 3, 15, 7, 11), "[0 2 1 2 1]"},
+	}
+	for _, c := range cases {
+		if got := fmt.Sprint(WeightDistribution(c.s)); got != c.want {
+			t.Errorf("WeightDistribution(%v) = %v, want %v", c.s, got, c.want)
+		}
+	}
+}
+
+func TestSpanWeightDistribution(t *testing.T) {
+	s7 := NewGF2VectorSpace(7)
+	cases := []struct {
+		span *GF2Span
+		want string
+	}{
+		{s7.NewGF2Span(), "[1 0 0 0 0 0 0 0]"},
+		// Hamming [7,4] code
+		{SpanOf(vectors(s7, 0x43, 0x25, 0x16, 0x0f)), "[1 0 0 7 7 0 0 1]"},
+		// its dual, the simplex code
+		{SpanOf(vectors(s7, 0x43, 0x25, 0x16, 0x0f)).OrthogonalComplement(), "[1 0 0 0 7 0 0 0]"},
+		{SpanOf(vectors(NewGF2VectorSpace(4), 1, 2, 4, 8)), "[1 4 6 4 1]"},
+	}
+	for _, c := range cases {
+		if got := fmt.Sprint(c.span.WeightDistribution()); got != c.want {
+			t.Errorf("%v.WeightDistribution() = %v, want %v", c.span, got, c.want)
+		}
+	}
+	s := NewGF2VectorSpace(70)
+	base := make([]*GF2Vector, 70)
+	for i := range base {
+		base[i] = s.GF2BaseVector(uint(i) + 1)
+	}
+	// the limit 32 and the shift of Dim() >= 64 bits
+	for _, d := range []int{33, 64, 70} {
+		func() {
+			defer func() {
+				if got, want := recover(), fmt.Sprintf("WeightDistribution(): Dim() = %v > 32", d); got != want {
+					t.Errorf("WeightDistribution() of Dim() = %v panic %v, want %v", d, got, want)
+				}
+			}()
+			SpanOf(base[:d]).WeightDistribution()
+		}()
+	}
+}
+
+func TestBallSize(t *testing.T) {
+	cases := []struct {
+		dim, r uint
+		want   uint64
+	}{
+		{1, 0, 1},
+		{1, 5, 2},
+		{10, 2, 56},
+		{64, 1, 65},
+		{64, 3, 1 + 64 + 2016 + 41664},
+		{63, 63, 1 << 63},
+		{64, 64, math.MaxUint64},
+		{200, 100, math.MaxUint64},
+	}
+	for _, c := range cases {
+		if got := NewGF2VectorSpace(c.dim).BallSize(c.r); got != c.want {
+			t.Errorf("%v.BallSize(%v) = %v, want %v", c.dim, c.r, got, c.want)
+		}
+	}
+}
+
+func TestBallSphere(t *testing.T) {
+	r := rand.New(rand.NewPCG(5, 6))
+	for _, c := range []struct{ dim, r uint }{{1, 1}, {5, 2}, {8, 8}, {64, 2}, {70, 2}, {130, 1}} {
+		sp := NewGF2VectorSpace(c.dim)
+		center := randomVector(r, sp, 2)
+		seen := make(map[string]bool)
+		d := 0
+		for v := range center.Ball(c.r) {
+			dist := Distance(center, v)
+			if dist < d || dist > int(c.r) || seen[v.String()] {
+				t.Fatalf("%v.Ball(%v) yield %v at distance %v", center, c.r, v, dist)
+			}
+			d = dist
+			seen[v.String()] = true
+		}
+		if n := uint64(len(seen)); n != sp.BallSize(c.r) {
+			t.Errorf("%v.Ball(%v) yield %v vectors, want %v", center, c.r, n, sp.BallSize(c.r))
+		}
+		n := 0
+		for v := range center.Sphere(c.r) {
+			if Distance(center, v) != int(c.r) {
+				t.Fatalf("%v.Sphere(%v) yield %v", center, c.r, v)
+			}
+			n++
+		}
+		if want := binomial(c.dim, c.r); uint64(n) != want {
+			t.Errorf("%v.Sphere(%v) yield %v vectors, want %v", center, c.r, n, want)
+		}
+	}
+
+	// order and break
+	center := NewGF2VectorSpace(3).NewGF2Vector(0b101)
+	var got []string
+	for v := range center.Ball(3) {
+		got = append(got, v.String())
+		if len(got) == 5 {
+			break
+		}
+	}
+	if want := "[101 100 111 001 110]"; fmt.Sprint(got) != want {
+		t.Errorf("%v.Ball(3) = %v, want %v", center, got, want)
+	}
+}
+
+func TestBallAllocations(t *testing.T) {
+	center := NewGF2VectorSpace(64).NewGF2Vector(0xdeadbeef)
+	allocs := testing.AllocsPerRun(10, func() {
+		for v := range center.Ball(3) {
+			sinkVector = v
+		}
+	})
+	if allocs > 4 {
+		t.Errorf("Ball(3) allocations = %v, want at most 4", allocs)
+	}
+}
@@ -0,0 +1,144 @@
+// Ralf Poeppel, 2026
+//
+// This file implements the test of linear independence of a set of vectors.
+// Gaussian elimination is used, for each reduced vector the combination of
+// the input vectors adding up to it is tracked as vector of a vector space
+// with the count of input vectors as dimension.
+// Steinitz exchange lemma:
+// https://en.wikipedia.org/w/index.php?title=Steinitz_exchange_lemma&oldid=1336271854
+
+package gf2vs
+
+import (
+	"slices"
+)
+
+// combRow a reduced vector and the combination of the input vectors adding up to it.
+type combRow struct {
+	vec  *GF2Vector // reduced vector
+	comb *GF2Vector // bit i is set, if input vector i is part of the sum
+	lead uint       // leading coordinate of vec
+}
+
+// eliminator holds the rows of the elimination ordered by leading coordinate descending.
+type eliminator struct {
+	rows []combRow
+	cs   *GF2VectorSpace // vector space of the combinations
+}
+
+// newEliminator create an eliminator for n input vectors.
+func newEliminator(n int) *eliminator {
+	return &eliminator{cs: NewGF2VectorSpace(uint(max(n, 1)))}
+}
+
+// add reduce the input vector v with index i by the rows.
+// Return nil if v is independent of the previous input vectors, it is
+// added as new row then. Otherwise return the combination of the input
+// vectors adding up to zero.
+func (e *eliminator) add(i int, v *GF2Vector) *GF2Vector {
+	w := v.Copy()
+	c := e.cs.GF2BaseVector(uint(i) + 1)
+	for _, r := range e.rows {
+		if w.Bit(r.lead) == 1 {
+			w.Xor(w, r.vec)
+			c.Xor(c, r.comb)
+		}
+	}
+	p := leading(w)
+	if p == 0 {
+		return c
+	}
+	j, _ := slices.BinarySearchFunc(e.rows, p, func(r combRow, p uint) int {
+		return int(p) - int(r.lead)
+	})
+	e.rows = slices.Insert(e.rows, j, combRow{w, c, p})
+	return nil
+}
+
+// indices return the 0-based indices of the set coordinates of c.
+func indices(c *GF2Vector) []int {
+	var idx []int
+	for i := range c.Ones() {
+		idx = append(idx, int(i)-1)
+	}
+	return idx
+}
+
+// mustSameSpace panic if the vectors of s are of vector spaces of different dimension.
+func mustSameSpace(op string, s []*GF2Vector) {
+	if len(s) == 0 {
+		return
+	}
+	if err := checkSpaces(op, s); err != nil {
+		panic(err.Error())
+	}
+}
+
+// IsLinearIndependent return true if the vectors of s are linearly independent.
+// The empty set is independent.
+// Panic if x_i and x_j are of vector spaces of different dimension.
+func IsLinearIndependent(s []*GF2Vector) bool {
+	_, ok := LinearDependency(s)
+	return !ok
+}
+
+// RankOf return the rank of the set of vectors s,
+// the dimension of the sub vector space spanned by s.
+// Panic if x_i and x_j are of vector spaces of different dimension.
+func RankOf(s []*GF2Vector) uint {
+	return uint(len(MaximalIndependentSubset(s)))
+}
+
+// MaximalIndependentSubset return the indices in ascending order of a maximal
+// linearly independent subset of s. Each vector is taken if it is independent
+// of the vectors taken before, so the subset is a basis of the span of s.
+// Panic if x_i and x_j are of vector spaces of different dimension.
+func MaximalIndependentSubset(s []*GF2Vector) []int {
+	mustSameSpace("MaximalIndependentSubset", s)
+	e := newEliminator(len(s))
+	var idx []int
+	for i, v := range s {
+		if e.add(i, v) == nil {
+			idx = append(idx, i)
+		}
+	}
+	return idx
+}
+
+// LinearDependency return the indices in ascending order of vectors of s,
+// which add up to the zero vector, and true.
+// The dependency found first, with the smallest largest index, is returned.
+// If s is linearly independent nil and false are returned.
+// Panic if x_i and x_j are of vector spaces of different dimension.
+func LinearDependency(s []*GF2Vector) (dep []int, ok bool) {
+	mustSameSpace("LinearDependency", s)
+	e := newEliminator(len(s))
+	for i, v := range s {
+		if c := e.add(i, v); c != nil {
+			return indices(c), true
+		}
+	}
+	return nil, false
+}
+
+// SteinitzExchange return the indices in ascending order of vectors of b,
+// which extend the linearly independent set l to a basis of the span of
+// l and b. If b is a basis containing l in its span, len(b) - len(l)
+// indices are returned, so l replaces len(l) vectors of b.
+// Return nil and false if l is not linearly independent.
+// Panic if x_i and x_j are of vector spaces of different dimension.
+func SteinitzExchange(l, b []*GF2Vector) (idx []int, ok bool) {
+	mustSameSpace("SteinitzExchange", slices.Concat(l, b))
+	e := newEliminator(len(l) + len(b))
+	for i, v := range l {
+		if e.add(i, v) != nil {
+			return nil, false
+		}
+	}
+	for i, v := range b {
+		if e.add(len(l)+i, v) == nil {
+			idx = append(idx, i)
+		}
+	}
+	return idx, true
+}
@@ -0,0 +1,97 @@
+// Ralf Poeppel, 2026
+
+package gf2vs
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestLinearIndependence(t *testing.T) {
+	cases := []struct {
+		dim    uint
+		s      []uint
+		indep  bool
+		subset []int
+		dep    []int
+	}{
+		{3, nil, true, nil, nil},
+		{3, []uint{0}, false, nil, []int{0}},
+		{3, []uint{1}, true, []int{0}, nil},
+		{3, []uint{0b011, 0b110}, true, []int{0, 1}, nil},
+		{3, []uint{0b011, 0b110, 0b101}, false, []int{0, 1}, []int{0, 1, 2}},
+		{3, []uint{0b011, 0b011, 0b100}, false, []int{0, 2}, []int{0, 1}},
+		{3, []uint{0b001, 0b010, 0b100, 0b111}, false, []int{0, 1, 2}, []int{0, 1, 2, 3}},
+		{4, []uint{0b1100, 0b0001, 0b0110, 0b1010}, false, []int{0, 1, 2}, []int{0, 2, 3}},
+		{4, []uint{0b1000, 0b0100, 0b0010, 0b0001}, true, []int{0, 1, 2, 3}, nil},
+	}
+	for _, c := range cases {
+		sp := NewGF2VectorSpace(c.dim)
+		s := vectors(sp, c.s...)
+		if got := IsLinearIndependent(s); got != c.indep {
+			t.Errorf("IsLinearIndependent(%v) = %v, want %v", s, got, c.indep)
+		}
+		if got := MaximalIndependentSubset(s); !slices.Equal(got, c.subset) {
+			t.Errorf("MaximalIndependentSubset(%v) = %v, want %v", s, got, c.subset)
+		}
+		if got := RankOf(s); got != uint(len(c.subset)) {
+			t.Errorf("RankOf(%v) = %v, want %v", s, got, len(c.subset))
+		}
+		dep, ok := LinearDependency(s)
+		if !slices.Equal(dep, c.dep) || ok == c.indep {
+			t.Errorf("LinearDependency(%v) = %v, %v, want %v, %v", s, dep, ok, c.dep, !c.indep)
+		}
+		if ok {
+			sum := sp.GF2Zeros()
+			for _, i := range dep {
+				sum.Xor(sum, s[i])
+			}
+			if !sum.IsZeros() {
+				t.Errorf("LinearDependency(%v) = %v, sum is %v", s, dep, sum)
+			}
+		}
+	}
+}
+
+func TestWideLinearDependency(t *testing.T) {
+	sp := NewGF2VectorSpace(1000)
+	s := []*GF2Vector{sp.GF2BaseVector(1000), sp.GF2BaseVector(1), sp.GF2BaseVector(500)}
+	s = append(s, Xor(s[0], s[2]))
+	dep, ok := LinearDependency(s)
+	if !ok || !slices.Equal(dep, []int{0, 2, 3}) {
+		t.Errorf("LinearDependency(e1000, e1, e500, e1000 + e500) = %v, %v, want [0 2 3], true", dep, ok)
+	}
+}
+
+func TestSteinitzExchange(t *testing.T) {
+	cases := []struct {
+		dim uint
+		l   []uint
+		b   []uint
+		idx []int
+		ok  bool
+	}{
+		{3, nil, []uint{1, 2, 4}, []int{0, 1, 2}, true},
+		{3, []uint{0b111}, []uint{1, 2, 4}, []int{0, 1}, true},
+		{3, []uint{0b011, 0b110}, []uint{1, 2, 4}, []int{0}, true},
+		{3, []uint{0b011, 0b110, 0b101}, []uint{1, 2, 4}, nil, false},
+		{4, []uint{0b0011}, []uint{0b0001, 0b0010}, []int{0}, true},
+	}
+	for _, c := range cases {
+		sp := NewGF2VectorSpace(c.dim)
+		l := vectors(sp, c.l...)
+		b := vectors(sp, c.b...)
+		idx, ok := SteinitzExchange(l, b)
+		if !slices.Equal(idx, c.idx) || ok != c.ok {
+			t.Errorf("SteinitzExchange(%v, %v) = %v, %v, want %v, %v", l, b, idx, ok, c.idx, c.ok)
+		}
+	}
+
+	want := "LinearDependency: incompatible vector spaces: z.dim = 1 != 2 = y.dim"
+	defer func() {
+		if r := recover(); r != want {
+			t.Errorf("LinearDependency(1, 11) == Panic(%v), want Panic(%v)", r, want)
+		}
+	}()
+	LinearDependency([]*GF2Vector{NewGF2VectorSpace(1).GF2Ones(), NewGF2VectorSpace(2).GF2Ones()})
+}
@@ -0,0 +1,311 @@
+// Ralf Poeppel, 2026
+//
+// This file implements the tests of polynomials over GF(2) for irreducibility
+// and primitivity, and the search of irreducible and primitive polynomials.
+// Rabin's test of irreducibility: a polynomial f of degree n is irreducible,
+// if x^(2^n) = x mod f and gcd(x^(2^(n/q)) - x, f) = 1 for each prime q of n.
+// https://en.wikipedia.org/w/index.php?title=Factorization_of_polynomials_over_finite_fields&oldid=1329658423#Rabin's_test_of_irreducibility
+// An irreducible polynomial f of degree n is primitive, if x has order 2^n - 1
+// modulo f: x^((2^n - 1)/q) != 1 mod f for each prime q of 2^n - 1.
+// The primes of 2^n - 1 are found by trial division and Pollard's rho algorithm,
+// which is fast for the degrees of practical use.
+
+package gf2vs
+
+import (
+	"iter"
+	"math/big"
+	"slices"
+	"sync"
+)
+
+// smallPrimes the primes used for trial division.
+var smallPrimes = func() []int64 {
+	var ps []int64
+	for n := int64(2); n < 1000; n++ {
+		if big.NewInt(n).ProbablyPrime(0) {
+			ps = append(ps, n)
+		}
+	}
+	return ps
+}()
+
+// primeFactors return the distinct prime factors of n > 0 ascending.
+func primeFactors(n *big.Int) []*big.Int {
+	var fs []*big.Int
+	n = new(big.Int).Set(n)
+	q, r := new(big.Int), new(big.Int)
+	for _, p := range smallPrimes {
+		bp := big.NewInt(p)
+		if q.DivMod(n, bp, r); r.Sign() != 0 {
+			continue
+		}
+		fs = append(fs, bp)
+		for r.Sign() == 0 {
+			n.Set(q)
+			q.DivMod(n, bp, r)
+		}
+	}
+	fs = rhoFactors(n, fs)
+	slices.SortFunc(fs, (*big.Int).Cmp)
+	return slices.CompactFunc(fs, func(a, b *big.Int) bool { return a.Cmp(b) == 0 })
+}
+
+// rhoFactors append the prime factors of n to fs and return fs.
+func rhoFactors(n *big.Int, fs []*big.Int) []*big.Int {
+	one := big.NewInt(1)
+	if n.Cmp(one) == 0 {
+		return fs
+	}
+	if n.ProbablyPrime(20) {
+		return append(fs, n)
+	}
+	d := pollardRho(n)
+	fs = rhoFactors(d, fs)
+	return rhoFactors(new(big.Int).Quo(n, d), fs)
+}
+
+// pollardRho return a proper divisor of the composite number n,
+// Pollard's rho algorithm with the products of 64 differences per gcd.
+// https://en.wikipedia.org/w/index.php?title=Pollard%27s_rho_algorithm&oldid=1322853829
+func pollardRho(n *big.Int) *big.Int {
+	one := big.NewInt(1)
+	for c := int64(1); ; c++ {
+		bc := big.NewInt(c)
+		f := func(x *big.Int) *big.Int {
+			x.Mul(x, x)
+			x.Add(x, bc)
+			return x.Mod(x, n)
+		}
+		x, y := big.NewInt(2), big.NewInt(2)
+		d, diff := new(big.Int), new(big.Int)
+		for d.Set(one); d.Cmp(one) == 0; {
+			xs, ys := new(big.Int).Set(x), new(big.Int).Set(y)
+			q := big.NewInt(1)
+			for range 64 {
+				f(x)
+				f(f(y))
+				q.Mul(q, diff.Sub(x, y).Abs(diff))
+				q.Mod(q, n)
+			}
+			d.GCD(nil, nil, q, n)
+			if d.Cmp(n) == 0 {
+				// repeat the steps one by one
+				x, y = xs, ys
+				for d.Cmp(one) == 0 || d.Cmp(n) == 0 {
+					f(x)
+					f(f(y))
+					d.GCD(nil, nil, diff.Sub(x, y).Abs(diff), n)
+					if d.Cmp(n) == 0 {
+						break
+					}
+				}
+			}
+		}
+		if d.Cmp(n) != 0 {
+			return d
+		}
+	}
+}
+
+// IsIrreducible return true if p is irreducible, p has degree at least 1
+// and is no product of polynomials of smaller degree. Rabin's test is used.
+func (p *GF2Poly) IsIrreducible() bool {
+	n := p.Degree()
+	switch {
+	case n < 1:
+		return false
+	case n == 1:
+		return true
+	case p.Coeff(0) == 0:
+		return false // x is a factor
+	}
+	var ks []int // n/q for the primes q of n
+	for _, q := range primeFactors(big.NewInt(int64(n))) {
+		ks = append(ks, n/int(q.Int64()))
+	}
+	x := NewGF2Poly(1)
+	h, g, d := NewGF2Poly(1), new(GF2Poly), new(GF2Poly)
+	for k := 1; k <= n; k++ {
+		h.Mod(h.Sqr(h), p) // h = x^(2^k) mod p
+		if slices.Contains(ks, k) && !g.GCD(nil, nil, d.Add(h, x), p).IsOne() {
+			return false
+		}
+	}
+	return h.Equal(x)
+}
+
+// mersenne return 2^n - 1.
+func mersenne(n int) *big.Int {
+	m := new(big.Int).Lsh(big.NewInt(1), uint(n))
+	return m.Sub(m, big.NewInt(1))
+}
+
+// IsPrimitive return true if p is a primitive polynomial, an irreducible
+// polynomial of degree n with a root generating the multiplicative group of
+// the field with 2^n elements. Then the order of x modulo p is 2^n - 1.
+// The primes of 2^n - 1 are computed, which is expensive for large n.
+func (p *GF2Poly) IsPrimitive() bool {
+	n := p.Degree()
+	if n < 1 {
+		return false
+	}
+	return p.isPrimitive(func() []*big.Int { return primeFactors(mersenne(n)) })
+}
+
+// isPrimitive return true if p is primitive, fs return the primes of 2^n - 1,
+// it is called only for an irreducible p.
+func (p *GF2Poly) isPrimitive(fs func() []*big.Int) bool {
+	if p.Coeff(0) == 0 || !p.IsIrreducible() {
+		return false
+	}
+	m := mersenne(p.Degree())
+	x, e, h := NewGF2Poly(1), new(big.Int), new(GF2Poly)
+	for _, q := range fs() {
+		if h.ModExp(x, e.Quo(m, q), p).IsOne() {
+			return false
+		}
+	}
+	return true
+}
+
+// polynomials return an iterator over the polynomials x^n + ... + 1
+// of degree n >= 2 ascending, which satisfy ok.
+func polynomials(n uint, ok func(*GF2Poly) bool) iter.Seq[*GF2Poly] {
+	return func(yield func(*GF2Poly) bool) {
+		if n < 2 {
+			return
+		}
+		c, one := new(big.Int), big.NewInt(1)
+		end := new(big.Int).Lsh(one, n-1)
+		for ; c.Cmp(end) < 0; c.Add(c, one) {
+			w := make([]uint, len(c.Bits()))
+			for i, b := range c.Bits() {
+				w[i] = uint(b)
+			}
+			p := NewGF2PolyWords(w)
+			p.Lsh(p, 1).SetCoeff(n, 1).SetCoeff(0, 1)
+			if ok(p) && !yield(p) {
+				return
+			}
+		}
+	}
+}
+
+// polynomialsOfWeight return an iterator over the polynomials
+// x^n + ... + 1 of degree n >= 2 with w coefficients set ascending,
+// which satisfy ok.
+func polynomialsOfWeight(n, w uint, ok func(*GF2Poly) bool) iter.Seq[*GF2Poly] {
+	return func(yield func(*GF2Poly) bool) {
+		if n < 2 || w < 2 {
+			return
+		}
+		for v := range NewGF2VectorSpace(n - 1).ConstantWeight(w - 2) {
+			p := NewGF2PolyWords(wordsOf(v))
+			p.Lsh(p, 1).SetCoeff(n, 1).SetCoeff(0, 1)
+			if ok(p) && !yield(p) {
+				return
+			}
+		}
+	}
+}
+
+// degreeOne return the polynomials of degree 1 of polynomials of weight w,
+// which satisfy ok.
+func degreeOne(w uint, ok func(*GF2Poly) bool) []*GF2Poly {
+	var ps []*GF2Poly
+	for _, p := range []*GF2Poly{NewGF2Poly(1), NewGF2Poly(1, 0)} {
+		if (w == 0 || uint(p.Weight()) == w) && ok(p) {
+			ps = append(ps, p)
+		}
+	}
+	return ps
+}
+
+// ofDegree return an iterator over the polynomials of degree n ascending,
+// of weight w or any weight for w = 0, which satisfy ok.
+func ofDegree(n, w uint, ok func(*GF2Poly) bool) iter.Seq[*GF2Poly] {
+	if n == 1 {
+		return slices.Values(degreeOne(w, ok))
+	}
+	if w == 0 {
+		return polynomials(n, ok)
+	}
+	return polynomialsOfWeight(n, w, ok)
+}
+
+// minimalWeight return the smallest polynomial of degree n of minimal weight,
+// which satisfy ok, or nil if there is none.
+// Polynomials of even weight are divisible by x + 1, so odd weights are searched.
+func minimalWeight(n uint, ok func(*GF2Poly) bool) *GF2Poly {
+	if n == 1 {
+		for w := uint(1); w <= 2; w++ {
+			if ps := degreeOne(w, ok); len(ps) > 0 {
+				return ps[0]
+			}
+		}
+	}
+	for w := uint(3); w <= n+1; w += 2 {
+		for p := range polynomialsOfWeight(n, w, ok) {
+			return p
+		}
+	}
+	return nil
+}
+
+// isPrimitiveOf return a test of polynomials of degree n on primitivity,
+// the primes of 2^n - 1 are computed once, on the test of the first
+// irreducible polynomial, not when the test or an iterator is created.
+func isPrimitiveOf(n uint) func(*GF2Poly) bool {
+	if n == 0 {
+		return func(*GF2Poly) bool { return false }
+	}
+	fs := sync.OnceValue(func() []*big.Int { return primeFactors(mersenne(int(n))) })
+	return func(p *GF2Poly) bool { return p.isPrimitive(fs) }
+}
+
+// IrreduciblePolynomials return an iterator over the irreducible polynomials
+// of degree n in ascending order, the first is the smallest.
+func IrreduciblePolynomials(n uint) iter.Seq[*GF2Poly] {
+	return ofDegree(n, 0, (*GF2Poly).IsIrreducible)
+}
+
+// IrreduciblePolynomialsOfWeight return an iterator over the irreducible
+// polynomials of degree n with w coefficients set in ascending order.
+func IrreduciblePolynomialsOfWeight(n, w uint) iter.Seq[*GF2Poly] {
+	if w == 0 {
+		return slices.Values([]*GF2Poly(nil))
+	}
+	return ofDegree(n, w, (*GF2Poly).IsIrreducible)
+}
+
+// MinimalWeightIrreduciblePolynomial return the smallest irreducible polynomial
+// of degree n with the least count of coefficients set, a trinomial if there is
+// one, otherwise a pentanomial if there is one and so on.
+// Return nil for n = 0.
+func MinimalWeightIrreduciblePolynomial(n uint) *GF2Poly {
+	return minimalWeight(n, (*GF2Poly).IsIrreducible)
+}
+
+// PrimitivePolynomials return an iterator over the primitive polynomials
+// of degree n in ascending order, the first is the smallest.
+func PrimitivePolynomials(n uint) iter.Seq[*GF2Poly] {
+	return ofDegree(n, 0, isPrimitiveOf(n))
+}
+
+// PrimitivePolynomialsOfWeight return an iterator over the primitive
+// polynomials of degree n with w coefficients set in ascending order.
+func PrimitivePolynomialsOfWeight(n, w uint) iter.Seq[*GF2Poly] {
+	if w == 0 {
+		return slices.Values([]*GF2Poly(nil))
+	}
+	return ofDegree(n, w, isPrimitiveOf(n))
+}
+
+// MinimalWeightPrimitivePolynomial return the smallest primitive polynomial
+// of degree n with the least count of coefficients set, a trinomial if there
+// is one, otherwise a pentanomial if there is one and so on.
+// Return nil for n = 0.
+func MinimalWeightPrimitivePolynomial(n uint) *GF2Poly {
+	return minimalWeight(n, isPrimitiveOf(n))
+}
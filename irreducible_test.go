@@ -0,0 +1,164 @@
+// Ralf Poeppel, 2026
+
+package gf2vs
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+)
+
+func TestPrimeFactors(t *testing.T) {
+	cases := []struct {
+		n    *big.Int
+		want string
+	}{
+		{big.NewInt(1), "[]"},
+		{big.NewInt(12), "[2 3]"},
+		{big.NewInt(997 * 997), "[997]"},
+		{mersenne(11), "[23 89]"},
+		{mersenne(64), "[3 5 17 257 641 65537 6700417]"},
+		{mersenne(67), "[193707721 761838257287]"},
+		{mersenne(127), "[170141183460469231731687303715884105727]"},
+		{mersenne(128), "[3 5 17 257 641 65537 274177 6700417 67280421310721]"},
+	}
+	for _, c := range cases {
+		if got := fmt.Sprint(primeFactors(c.n)); got != c.want {
+			t.Errorf("primeFactors(%v) = %v, want %v", c.n, got, c.want)
+		}
+	}
+}
+
+func TestIsIrreduciblePrimitive(t *testing.T) {
+	cases := []struct {
+		p           *GF2Poly
+		irreducible bool
+		primitive   bool
+	}{
+		{new(GF2Poly), false, false},
+		{NewGF2Poly(0), false, false},
+		{NewGF2Poly(1), true, false},
+		{NewGF2Poly(1, 0), true, true},
+		{NewGF2Poly(2, 1, 0), true, true},
+		{NewGF2Poly(2, 0), false, false},
+		{NewGF2Poly(4, 1, 0), true, true},
+		{NewGF2Poly(4, 3, 2, 1, 0), true, false},
+		{NewGF2Poly(4, 2, 0), false, false},
+		{NewGF2Poly(6, 3, 0), true, false},
+		{NewGF2Poly(8, 4, 3, 1, 0), true, false}, // AES
+		{NewGF2Poly(8, 4, 3, 2, 0), true, true},
+		{NewGF2Poly(8, 1, 0), false, false},
+		{NewGF2Poly(32, 26, 23, 22, 16, 12, 11, 10, 8, 7, 5, 4, 2, 1, 0), true, true}, // CRC-32
+		{NewGF2Poly(64, 4, 3, 1, 0), true, true},
+		{NewGF2Poly(127, 1, 0), true, true},
+		{NewGF2Poly(128, 7, 2, 1, 0), true, true}, // GCM
+		{new(GF2Poly).Mul(NewGF2Poly(127, 1, 0), NewGF2Poly(4, 1, 0)), false, false},
+	}
+	for _, c := range cases {
+		if got := c.p.IsIrreducible(); got != c.irreducible {
+			t.Errorf("%v.IsIrreducible() = %v, want %v", c.p, got, c.irreducible)
+		}
+		if got := c.p.IsPrimitive(); got != c.primitive {
+			t.Errorf("%v.IsPrimitive() = %v, want %v", c.p, got, c.primitive)
+		}
+	}
+}
+
+func TestCountIrreduciblePrimitive(t *testing.T) {
+	// OEIS A001037 and A011260
+	irreducible := []int{0, 2, 1, 2, 3, 6, 9, 18, 30, 56, 99}
+	primitive := []int{0, 1, 1, 2, 2, 6, 6, 18, 16, 48, 60}
+	for n := range uint(len(irreducible)) {
+		count := 0
+		for p := range IrreduciblePolynomials(n) {
+			if p.Degree() != int(n) || !p.IsIrreducible() {
+				t.Errorf("IrreduciblePolynomials(%v) yield %v", n, p)
+			}
+			count++
+		}
+		if count != irreducible[n] {
+			t.Errorf("IrreduciblePolynomials(%v) yield %v polynomials, want %v", n, count, irreducible[n])
+		}
+		count = 0
+		var prev *GF2Poly
+		for p := range PrimitivePolynomials(n) {
+			if prev != nil && prev.Cmp(p) >= 0 {
+				t.Errorf("PrimitivePolynomials(%v) yield %v after %v", n, p, prev)
+			}
+			prev = p
+			count++
+		}
+		if count != primitive[n] {
+			t.Errorf("PrimitivePolynomials(%v) yield %v polynomials, want %v", n, count, primitive[n])
+		}
+	}
+}
+
+func TestPolynomialsOfWeight(t *testing.T) {
+	cases := []struct {
+		n, w      uint
+		primitive bool
+		want      string
+	}{
+		{8, 5, true, "[x^8 + x^4 + x^3 + x^2 + 1 x^8 + x^5 + x^3 + x + 1 x^8 + x^5 + x^3 + x^2 + 1]"},
+		{8, 5, false, "[x^8 + x^4 + x^3 + x + 1 x^8 + x^4 + x^3 + x^2 + 1 x^8 + x^5 + x^3 + x + 1]"},
+		{8, 3, false, "[]"},
+		{7, 3, true, "[x^7 + x + 1 x^7 + x^3 + 1 x^7 + x^4 + 1]"},
+		{1, 1, false, "[x]"},
+		{1, 2, true, "[x + 1]"},
+		{1, 0, true, "[]"},
+		{0, 1, false, "[]"},
+	}
+	for _, c := range cases {
+		seq := IrreduciblePolynomialsOfWeight(c.n, c.w)
+		if c.primitive {
+			seq = PrimitivePolynomialsOfWeight(c.n, c.w)
+		}
+		var got []*GF2Poly
+		for p := range seq {
+			if got = append(got, p); len(got) == 3 {
+				break
+			}
+		}
+		if fmt.Sprint(got) != c.want {
+			t.Errorf("OfWeight(%v, %v), primitive %v = %v, want %v", c.n, c.w, c.primitive, got, c.want)
+		}
+	}
+}
+
+func TestMinimalWeight(t *testing.T) {
+	cases := []struct {
+		n                      uint
+		irreducible, primitive string
+	}{
+		{0, "<nil>", "<nil>"},
+		{1, "x", "x + 1"},
+		{2, "x^2 + x + 1", "x^2 + x + 1"},
+		{8, "x^8 + x^4 + x^3 + x + 1", "x^8 + x^4 + x^3 + x^2 + 1"},
+		{16, "x^16 + x^5 + x^3 + x + 1", "x^16 + x^5 + x^3 + x^2 + 1"},
+		{31, "x^31 + x^3 + 1", "x^31 + x^3 + 1"},
+		{64, "x^64 + x^4 + x^3 + x + 1", "x^64 + x^4 + x^3 + x + 1"},
+		{127, "x^127 + x + 1", "x^127 + x + 1"},
+	}
+	for _, c := range cases {
+		if got := MinimalWeightIrreduciblePolynomial(c.n); fmt.Sprint(got) != c.irreducible {
+			t.Errorf("MinimalWeightIrreduciblePolynomial(%v) = %v, want %v", c.n, got, c.irreducible)
+		}
+		if got := MinimalWeightPrimitivePolynomial(c.n); fmt.Sprint(got) != c.primitive {
+			t.Errorf("MinimalWeightPrimitivePolynomial(%v) = %v, want %v", c.n, got, c.primitive)
+		}
+	}
+}
+
+func TestPrimitivePolynomialsLazy(t *testing.T) {
+	// the factorization of 2^400 - 1 takes minutes, it is not done
+	// to build the iterators nor for reducible polynomials
+	PrimitivePolynomials(400)
+	PrimitivePolynomialsOfWeight(400, 3)
+	for p := range PrimitivePolynomialsOfWeight(400, 2) {
+		t.Errorf("PrimitivePolynomialsOfWeight(400, 2) yield %v", p)
+	}
+	if p := NewGF2Poly(400, 0); p.IsPrimitive() {
+		t.Errorf("%v.IsPrimitive() = true", p)
+	}
+}
@@ -0,0 +1,303 @@
+// Ralf Poeppel, 2026
+//
+// This file implements linear feedback shift registers of length L with a
+// feedback polynomial p = x^L + a_(L-1) x^(L-1) + ... + a_1 x + a_0,
+// the characteristic polynomial of the output sequence:
+// s_(n+L) = a_(L-1) s_(n+L-1) + ... + a_0 s_n.
+// The Fibonacci form holds the next L bits of the sequence, coordinate i is
+// s_(n+i-1), the output is coordinate 1, the feedback is the inner product
+// of the state with the taps a. The Galois form holds a polynomial r mod p,
+// coordinate i is the coefficient of x^(i-1), the output is coordinate L,
+// a step multiplies r by x mod p.
+// A step is a linear map, the companion matrix of p, jumping ahead k steps
+// applies its k-th power.
+// The Berlekamp–Massey algorithm computes the shortest LFSR of a sequence.
+// https://en.wikipedia.org/w/index.php?title=Berlekamp%E2%80%93Massey_algorithm&oldid=1301234960
+
+package gf2vs
+
+import (
+	"fmt"
+	"math/big"
+	"math/bits"
+)
+
+// LFSRForm the form of a linear feedback shift register.
+type LFSRForm int
+
+const (
+	Fibonacci LFSRForm = iota // external xor, the state are the next bits of the sequence
+	Galois                    // internal xor, the state is a polynomial mod p
+)
+
+func (f LFSRForm) String() string {
+	switch f {
+	case Fibonacci:
+		return "Fibonacci"
+	case Galois:
+		return "Galois"
+	}
+	return fmt.Sprintf("LFSRForm(%d)", int(f))
+}
+
+// LFSR represents a linear feedback shift register.
+type LFSR struct {
+	form  LFSRForm
+	poly  *GF2Poly      // feedback polynomial of degree L
+	taps  *GF2Vector    // a_0, ..., a_(L-1), coordinate i is a_(i-1)
+	state *GF2Vector    // the register
+	step  *GF2LinearMap // companion matrix, computed on first Jump
+}
+
+// NewLFSR create the LFSR of form with feedback polynomial p and state,
+// the state is copied.
+// Panic if the degree of p is less than 1, form is unknown or state is
+// not of dimension deg(p), it is NewLFSRErr panicking with its error.
+func NewLFSR(form LFSRForm, p *GF2Poly, state *GF2Vector) *LFSR {
+	r, err := NewLFSRErr(form, p, state)
+	if err != nil {
+		panic(err.Error())
+	}
+	return r
+}
+
+// NewLFSRErr create the LFSR of form with feedback polynomial p and state,
+// the state is copied.
+// Return an error wrapping ErrValueOutOfRange if the degree of p is less
+// than 1 or form is unknown, ErrDimensionMismatch if state is not of
+// dimension deg(p).
+func NewLFSRErr(form LFSRForm, p *GF2Poly, state *GF2Vector) (*LFSR, error) {
+	const op = "NewLFSR(form, p, state)"
+	if form != Fibonacci && form != Galois {
+		return nil, outOfRange(op, "form = %v is unknown", form)
+	}
+	l := p.Degree()
+	if l < 1 {
+		return nil, outOfRange(op, "p = %v has degree less than 1", p)
+	}
+	if state.sp.dim != uint(l) {
+		return nil, mismatch(op, uint(l), state.sp.dim)
+	}
+	taps := state.sp.vectorOf(polyInt(new(GF2Poly).Set(p).SetCoeff(uint(l), 0)))
+	return &LFSR{form: form, poly: new(GF2Poly).Set(p), taps: taps, state: state.Copy()}, nil
+}
+
+func (r *LFSR) String() string {
+	return fmt.Sprintf("LFSR{%v %v: %v}", r.form, r.poly, r.state)
+}
+
+// Form return the form of r.
+func (r *LFSR) Form() LFSRForm {
+	return r.form
+}
+
+// Poly return a copy of the feedback polynomial of r.
+func (r *LFSR) Poly() *GF2Poly {
+	return new(GF2Poly).Set(r.poly)
+}
+
+// Len return the length L of r, the degree of the feedback polynomial.
+func (r *LFSR) Len() uint {
+	return r.state.sp.dim
+}
+
+// State return a copy of the state of r.
+func (r *LFSR) State() *GF2Vector {
+	return r.state.Copy()
+}
+
+// SetState set the state of r to a copy of v and return r.
+// Panic if v is not of dimension L.
+func (r *LFSR) SetState(v *GF2Vector) *LFSR {
+	sameSpace("SetState", r.state, v)
+	r.state.Set(v)
+	return r
+}
+
+// shiftDown shift the coordinates of v down by one in place, coordinate i+1
+// is coordinate i, and set the coordinate dim to b.
+func shiftDown(v *GF2Vector, b uint) {
+	if v.words == nil {
+		v.val = v.val>>1 | b<<(v.sp.dim-1)
+		return
+	}
+	w := v.words
+	for i := range len(w) - 1 {
+		w[i] = w[i]>>1 | w[i+1]<<(bits.UintSize-1)
+	}
+	w[len(w)-1] >>= 1
+	v.SetBit(v.sp.dim, b)
+}
+
+// shiftUp shift the coordinates of v up by one in place, coordinate i
+// is coordinate i+1, the coordinate dim is dropped and coordinate 1 is 0.
+func shiftUp(v *GF2Vector) {
+	if v.words == nil {
+		v.val = v.val << 1 & v.sp.ones
+		return
+	}
+	w := v.words
+	for i := len(w) - 1; i > 0; i-- {
+		w[i] = w[i]<<1 | w[i-1]>>(bits.UintSize-1)
+	}
+	w[0] <<= 1
+	w[len(w)-1] &= v.sp.ones
+}
+
+// Step advance r by one step and return the output bit.
+// The state is shifted in place, Step does not allocate.
+func (r *LFSR) Step() uint {
+	s, l := r.state, r.state.sp.dim
+	if r.form == Fibonacci {
+		out, fb := s.Bit(1), Dot(r.taps, s)
+		shiftDown(s, fb)
+		return out
+	}
+	out := s.Bit(l)
+	shiftUp(s)
+	if out == 1 {
+		s.Xor(s, r.taps)
+	}
+	return out
+}
+
+// Bits return the next n output bits of r.
+func (r *LFSR) Bits(n int) []uint {
+	bs := make([]uint, n)
+	for i := range bs {
+		bs[i] = r.Step()
+	}
+	return bs
+}
+
+// XORKeyStream xor each byte of src with a byte of the output of r and
+// store the result in dst, the first output bit is the least significant
+// bit of a byte. dst and src may overlap entirely or not at all.
+// XORKeyStream implements cipher.Stream, it is a scrambler.
+// Panic if len(dst) < len(src).
+func (r *LFSR) XORKeyStream(dst, src []byte) {
+	if len(dst) < len(src) {
+		panic(fmt.Sprintf("XORKeyStream(dst, src): len(dst) = %v < %v = len(src)", len(dst), len(src)))
+	}
+	for i, b := range src {
+		var k byte
+		for j := range 8 {
+			k |= byte(r.Step()) << j
+		}
+		dst[i] = b ^ k
+	}
+}
+
+// companion return the linear map of a step of r, the companion matrix of p.
+func (r *LFSR) companion() *GF2LinearMap {
+	sp, l := r.state.sp, r.state.sp.dim
+	images := make([]*GF2Vector, l)
+	for i := range l {
+		// image of the base vector i+1
+		v := sp.GF2Zeros()
+		if r.form == Fibonacci {
+			if i > 0 {
+				v.SetBit(i, 1)
+			}
+			v.SetBit(l, r.taps.Bit(i+1))
+		} else if i+1 < l {
+			v.SetBit(i+2, 1)
+		} else {
+			v.Set(r.taps)
+		}
+		images[i] = v
+	}
+	return NewGF2LinearMapImages(sp, images)
+}
+
+// Jump advance r by k steps without output and return r. The state is
+// mapped by the k-th power of the companion matrix, computed by squaring.
+// Panic if k is negative.
+func (r *LFSR) Jump(k *big.Int) *LFSR {
+	if k.Sign() < 0 {
+		panic(fmt.Sprintf("Jump(k): k = %v is negative", k))
+	}
+	if r.step == nil {
+		r.step = r.companion()
+	}
+	m := r.step
+	for i := range k.BitLen() {
+		if k.Bit(i) == 1 {
+			r.state = m.Apply(r.state)
+		}
+		if i+1 < k.BitLen() {
+			m = m.Compose(m)
+		}
+	}
+	return r
+}
+
+// berlekampMassey return the connection polynomial C = 1 + c_1 x + ... + c_L x^L
+// of the shortest LFSR generating s, s_n = c_1 s_(n-1) + ... + c_L s_(n-L),
+// and the linear complexity L_k of s[:k] at profile[k-1].
+func berlekampMassey(s []uint) (c *GF2Poly, profile []int) {
+	c, b, t := NewGF2Poly(0), NewGF2Poly(0), new(GF2Poly)
+	l, m := 0, 1
+	profile = make([]int, len(s))
+	for n := range s {
+		d := s[n] & 1
+		for i := 1; i <= l; i++ {
+			d ^= c.Coeff(uint(i)) & s[n-i] & 1
+		}
+		switch {
+		case d == 0:
+			m++
+		case 2*l <= n:
+			t.Set(c)
+			c.Add(c, new(GF2Poly).Lsh(b, uint(m)))
+			l = n + 1 - l
+			b, t = t, b
+			m = 1
+		default:
+			c.Add(c, new(GF2Poly).Lsh(b, uint(m)))
+			m++
+		}
+		profile[n] = l
+	}
+	return c, profile
+}
+
+// BerlekampMassey return the connection polynomial
+// C = 1 + c_1 x + ... + c_L x^L of the shortest LFSR generating the bits s
+// and its length L, the linear complexity of s:
+// s_n = c_1 s_(n-1) + ... + c_L s_(n-L) for n in [L, len(s)).
+// The degree of C may be less than L. The feedback polynomial of the LFSR
+// is the reciprocal x^L C(1/x). The bits s[i] must be 0 or 1.
+func BerlekampMassey(s []uint) (c *GF2Poly, l int) {
+	c, profile := berlekampMassey(s)
+	if len(s) > 0 {
+		l = profile[len(s)-1]
+	}
+	return c, l
+}
+
+// LinearComplexityProfile return the linear complexity L_k of s[:k] at
+// element k-1, for k in [1, len(s)]. The bits s[i] must be 0 or 1.
+func LinearComplexityProfile(s []uint) []int {
+	_, profile := berlekampMassey(s)
+	return profile
+}
+
+// ShortestLFSR return the shortest LFSR of Fibonacci form generating the bits s,
+// its state are the first L bits of s. Return nil if s is zero, L = 0.
+// The bits s[i] must be 0 or 1.
+func ShortestLFSR(s []uint) *LFSR {
+	c, l := BerlekampMassey(s)
+	if l == 0 {
+		return nil
+	}
+	p := new(GF2Poly)
+	for i := range l + 1 {
+		p.SetCoeff(uint(l-i), c.Coeff(uint(i)))
+	}
+	state := NewGF2VectorSpace(uint(l)).GF2Zeros()
+	for i, b := range s[:l] {
+		state.SetBit(uint(i+1), b&1)
+	}
+	return NewLFSR(Fibonacci, p, state)
+}
@@ -0,0 +1,165 @@
+// Ralf Poeppel, 2026
+
+package gf2vs
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"math/rand/v2"
+	"testing"
+)
+
+func TestLFSRStep(t *testing.T) {
+	p := NewGF2Poly(4, 1, 0)
+	sp := NewGF2VectorSpace(4)
+	cases := []struct {
+		form LFSRForm
+		want string
+	}{
+		// s_(n+4) = s_(n+1) + s_n, start 1000 is s_0..s_3 = 0001
+		{Fibonacci, "[0 0 0 1 0 0 1 1 0 1 0 1 1 1 1 0 0 0 1]"},
+		{Galois, "[1 0 0 1 1 0 1 0 1 1 1 1 0 0 0 1 0 0 1]"},
+	}
+	for _, c := range cases {
+		r := NewLFSR(c.form, p, sp.NewGF2Vector(0b1000))
+		if got := fmt.Sprint(r.Bits(19)); got != c.want {
+			t.Errorf("%v: Bits(19) = %v, want %v", r, got, c.want)
+		}
+		if r.Form() != c.form || r.Len() != 4 || !r.Poly().Equal(p) {
+			t.Errorf("%v: Form() = %v, Len() = %v, Poly() = %v", r, r.Form(), r.Len(), r.Poly())
+		}
+	}
+}
+
+func TestLFSRRecurrence(t *testing.T) {
+	r := rand.New(rand.NewPCG(1, 2))
+	for _, p := range []*GF2Poly{NewGF2Poly(4, 1, 0), NewGF2Poly(8, 4, 3, 1, 0), NewGF2Poly(70, 5, 3, 1, 0), NewGF2Poly(127, 1, 0)} {
+		l := p.Degree()
+		sp := NewGF2VectorSpace(uint(l))
+		for _, form := range []LFSRForm{Fibonacci, Galois} {
+			state := randomVector(r, sp, 32)
+			s := NewLFSR(form, p, state).Bits(3 * l)
+			for n := range 2 * l {
+				b := s[n+l]
+				for j := range l {
+					b ^= p.Coeff(uint(j)) & s[n+j]
+				}
+				if b != 0 {
+					t.Errorf("%v %v: s_%v violates the recurrence", form, p, n+l)
+					break
+				}
+			}
+			if state.IsZeros() {
+				continue
+			}
+			g := ShortestLFSR(s)
+			if g == nil || !g.Poly().Equal(p) {
+				t.Errorf("%v %v: ShortestLFSR(s) = %v", form, p, g)
+				continue
+			}
+			if got := g.Bits(len(s)); fmt.Sprint(got) != fmt.Sprint(s) {
+				t.Errorf("%v %v: ShortestLFSR(s).Bits() = %v, want %v", form, p, got, s)
+			}
+		}
+	}
+}
+
+func TestLFSRJump(t *testing.T) {
+	r := rand.New(rand.NewPCG(1, 2))
+	for _, p := range []*GF2Poly{NewGF2Poly(4, 1, 0), NewGF2Poly(6, 3, 0), NewGF2Poly(70, 5, 3, 1, 0)} {
+		sp := NewGF2VectorSpace(uint(p.Degree()))
+		for _, form := range []LFSRForm{Fibonacci, Galois} {
+			for range 5 {
+				state := randomVector(r, sp, 32)
+				k := r.IntN(300)
+				a, b := NewLFSR(form, p, state), NewLFSR(form, p, state)
+				a.Bits(k)
+				b.Jump(big.NewInt(int64(k)))
+				if a.State().String() != b.State().String() {
+					t.Errorf("%v %v: Jump(%v) = %v, want %v", form, p, k, b.State(), a.State())
+				}
+			}
+		}
+	}
+	// the period of a primitive polynomial of degree n is 2^n - 1
+	p := NewGF2Poly(127, 1, 0)
+	state, _ := ParseGF2Vector(NewGF2VectorSpace(127), "0x123456789")
+	for _, form := range []LFSRForm{Fibonacci, Galois} {
+		a := NewLFSR(form, p, state)
+		if got := a.Jump(mersenne(127)).State(); got.String() != state.String() {
+			t.Errorf("%v %v: Jump(2^127 - 1) = %v, want %v", form, p, got, state)
+		}
+	}
+}
+
+func TestBerlekampMassey(t *testing.T) {
+	cases := []struct {
+		s       []uint
+		c       string
+		l       int
+		profile string
+	}{
+		{nil, "1", 0, "[]"},
+		{[]uint{0, 0, 0}, "1", 0, "[0 0 0]"},
+		{[]uint{0, 0, 0, 1}, "x^4 + 1", 4, "[0 0 0 4]"},
+		{[]uint{1, 1, 1, 1}, "x + 1", 1, "[1 1 1 1]"},
+		{[]uint{1, 0, 1, 0, 1, 0}, "x^2 + 1", 2, "[1 1 2 2 2 2]"},
+		{[]uint{0, 0, 1, 1, 0, 1, 1, 1, 0}, "x^5 + x^3 + 1", 5, "[0 0 3 3 3 3 3 5 5]"},
+	}
+	for _, c := range cases {
+		p, l := BerlekampMassey(c.s)
+		if p.String() != c.c || l != c.l {
+			t.Errorf("BerlekampMassey(%v) = %v, %v, want %v, %v", c.s, p, l, c.c, c.l)
+		}
+		if got := fmt.Sprint(LinearComplexityProfile(c.s)); got != c.profile {
+			t.Errorf("LinearComplexityProfile(%v) = %v, want %v", c.s, got, c.profile)
+		}
+	}
+	if ShortestLFSR([]uint{0, 0}) != nil {
+		t.Errorf("ShortestLFSR of zeros is not nil")
+	}
+}
+
+func TestLFSRXORKeyStream(t *testing.T) {
+	p := NewGF2Poly(7, 4, 0) // x^7 + x^4 + 1, the scrambler of IEEE 802.11
+	state := NewGF2VectorSpace(7).GF2Ones()
+	msg := []byte("scrambled and descrambled")
+	buf := make([]byte, len(msg))
+	NewLFSR(Fibonacci, p, state).XORKeyStream(buf, msg)
+	if string(buf) == string(msg) {
+		t.Errorf("XORKeyStream(%q) does not scramble", msg)
+	}
+	NewLFSR(Fibonacci, p, state).XORKeyStream(buf, buf)
+	if string(buf) != string(msg) {
+		t.Errorf("XORKeyStream twice = %q, want %q", buf, msg)
+	}
+	k := make([]byte, 1)
+	bits := NewLFSR(Fibonacci, p, state).Bits(8)
+	NewLFSR(Fibonacci, p, state).XORKeyStream(k, k)
+	for i, b := range bits {
+		if uint(k[0]>>i&1) != b {
+			t.Errorf("XORKeyStream(0) = %08b, want bits %v", k[0], bits)
+			break
+		}
+	}
+}
+
+func TestNewLFSRErr(t *testing.T) {
+	sp := NewGF2VectorSpace(4)
+	cases := []struct {
+		form  LFSRForm
+		p     *GF2Poly
+		state *GF2Vector
+		err   error
+	}{
+		{Fibonacci, NewGF2Poly(0), sp.GF2Zeros(), ErrValueOutOfRange},
+		{LFSRForm(2), NewGF2Poly(4, 1, 0), sp.GF2Zeros(), ErrValueOutOfRange},
+		{Galois, NewGF2Poly(5, 2, 0), sp.GF2Zeros(), ErrDimensionMismatch},
+	}
+	for _, c := range cases {
+		if _, err := NewLFSRErr(c.form, c.p, c.state); !errors.Is(err, c.err) {
+			t.Errorf("NewLFSRErr(%v, %v, %v) error = %v, want %v", c.form, c.p, c.state, err, c.err)
+		}
+	}
+}
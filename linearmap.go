@@ -0,0 +1,215 @@
+// Ralf Poeppel, 2026
+//
+// This file implements linear maps between vector spaces given by a BitMatrix.
+// The vectors are written as column vectors with the most significant
+// coordinate on top, as in the String of a GF2Vector. So row 0 of the matrix
+// holds the coefficients of the most significant coordinate of the image,
+// and the bit i-1 of a row is the coefficient of coordinate i of the argument.
+// The map of the identity matrix is the identity.
+
+package gf2vs
+
+import (
+	"fmt"
+	"math/big"
+	"math/bits"
+)
+
+// GF2LinearMap represents a linear map from vector space from to vector space to.
+type GF2LinearMap struct {
+	from *GF2VectorSpace // domain
+	to   *GF2VectorSpace // codomain
+	m    BitMatrix       // to.dim rows of from.dim columns
+}
+
+// intOf return the value of v as big.Int.
+func intOf(v *GF2Vector) *big.Int {
+	ws := wordsOf(v)
+	w := make([]big.Word, len(ws))
+	for i, x := range ws {
+		w[i] = big.Word(x)
+	}
+	return new(big.Int).SetBits(w)
+}
+
+// vectorOf return the vector of s with the value of x,
+// x must be of range [0, 2**s.dim).
+func (s *GF2VectorSpace) vectorOf(x *big.Int) *GF2Vector {
+	v := s.GF2Zeros()
+	for i, w := range x.Bits() {
+		if v.words == nil {
+			v.val = uint(w)
+		} else {
+			v.words[i] = uint(w)
+		}
+	}
+	return v
+}
+
+// rowDot return the inner product of GF(2) of a row of a BitMatrix and v.
+func rowDot(row *big.Int, v *GF2Vector) uint {
+	ws := wordsOf(v)
+	var p uint
+	for i, w := range row.Bits() {
+		p ^= uint(w) & ws[i]
+	}
+	return uint(bits.OnesCount(p) & 1)
+}
+
+// NewGF2LinearMap create the linear map from vector space from to vector
+// space to given by the matrix m. The matrix is copied.
+// Panic if m has not to.dim rows or a row has more than from.dim columns,
+// it is NewGF2LinearMapErr panicking with its error.
+func NewGF2LinearMap(from, to *GF2VectorSpace, m BitMatrix) *GF2LinearMap {
+	f, err := NewGF2LinearMapErr(from, to, m)
+	if err != nil {
+		panic(err.Error())
+	}
+	return f
+}
+
+// NewGF2LinearMapErr create the linear map from vector space from to vector
+// space to given by the matrix m. The matrix is copied.
+// Return an error wrapping ErrDimensionMismatch if m has not to.dim rows or
+// a row has more than from.dim columns.
+func NewGF2LinearMapErr(from, to *GF2VectorSpace, m BitMatrix) (*GF2LinearMap, error) {
+	const op = "NewGF2LinearMap(from, to, m)"
+	if uint(len(m)) != to.dim {
+		return nil, &VectorSpaceError{op,
+			fmt.Sprintf("len(m) = %v != %v = to.dim", len(m), to.dim),
+			ErrDimensionMismatch}
+	}
+	for i, row := range m {
+		if row.Sign() < 0 || uint(row.BitLen()) > from.dim {
+			return nil, &VectorSpaceError{op,
+				fmt.Sprintf("row %v = %v has more bits than from.dim = %v", i, row, from.dim),
+				ErrDimensionMismatch}
+		}
+	}
+	return &GF2LinearMap{from, to, *new(BitMatrix).Set(&m)}, nil
+}
+
+// NewGF2LinearMapImages create the linear map from vector space from,
+// mapping the base vector i to images[i-1].
+// Panic if there are not from.dim images or the images are of vector spaces
+// of different dimension.
+func NewGF2LinearMapImages(from *GF2VectorSpace, images []*GF2Vector) *GF2LinearMap {
+	if uint(len(images)) != from.dim {
+		panic(fmt.Sprintf("NewGF2LinearMapImages(from, images): "+
+			"len(images) = %v != %v = from.dim", len(images), from.dim))
+	}
+	mustSameSpace("NewGF2LinearMapImages", images)
+	to := images[0].sp
+	m := make(BitMatrix, to.dim)
+	for r := range m {
+		m[r] = new(big.Int)
+		i := to.dim - uint(r)
+		for j, v := range images {
+			m[r].SetBit(m[r], j, v.Bit(i))
+		}
+	}
+	return &GF2LinearMap{from, to, m}
+}
+
+func (f *GF2LinearMap) String() string {
+	return fmt.Sprintf("GF(2)map{%v -> %v:\n%v}", f.from.dim, f.to.dim, f.m.Text(2, "\n"))
+}
+
+// Domain return the vector space of the arguments of f.
+func (f *GF2LinearMap) Domain() *GF2VectorSpace {
+	return f.from
+}
+
+// Codomain return the vector space of the images of f.
+func (f *GF2LinearMap) Codomain() *GF2VectorSpace {
+	return f.to
+}
+
+// Matrix return a copy of the matrix of f.
+func (f *GF2LinearMap) Matrix() BitMatrix {
+	return *new(BitMatrix).Set(&f.m)
+}
+
+// Apply return the image f(v) of v.
+// Panic if v is not of the domain of f.
+func (f *GF2LinearMap) Apply(v *GF2Vector) *GF2Vector {
+	if v.sp.dim != f.from.dim {
+		panic(mismatch("Apply", f.from.dim, v.sp.dim).Error())
+	}
+	z := f.to.GF2Zeros()
+	for r, row := range f.m {
+		if rowDot(row, v) == 1 {
+			z.SetBit(f.to.dim-uint(r), 1)
+		}
+	}
+	return z
+}
+
+// Compose return the composition g∘f, the map v -> g(f(v)).
+// Panic if the codomain of f is not the domain of g.
+func (g *GF2LinearMap) Compose(f *GF2LinearMap) *GF2LinearMap {
+	if f.to.dim != g.from.dim {
+		panic(mismatch("Compose", g.from.dim, f.to.dim).Error())
+	}
+	m := make(BitMatrix, g.to.dim)
+	for r, row := range g.m {
+		m[r] = new(big.Int)
+		// add the rows of f of the coordinates set in the row of g
+		for k := range row.BitLen() {
+			if row.Bit(k) == 1 {
+				m[r].Xor(m[r], f.m[f.to.dim-uint(k)-1])
+			}
+		}
+	}
+	return &GF2LinearMap{f.from, g.to, m}
+}
+
+// rows return the rows of the matrix of f as vectors of the domain.
+func (f *GF2LinearMap) rows() []*GF2Vector {
+	rs := make([]*GF2Vector, len(f.m))
+	for r, row := range f.m {
+		rs[r] = f.from.vectorOf(row)
+	}
+	return rs
+}
+
+// Rank return the rank of f, the dimension of the image of f.
+func (f *GF2LinearMap) Rank() uint {
+	m := f.Matrix()
+	rank, _ := m.RowReducedEcholonForm(0)
+	return uint(rank)
+}
+
+// Kernel return the kernel of f, all vectors v with f(v) = 0.
+// It is the orthogonal complement of the span of the rows of the matrix.
+func (f *GF2LinearMap) Kernel() *GF2Span {
+	rowSpace := f.from.NewGF2Span()
+	for _, v := range f.rows() {
+		rowSpace.add(v)
+	}
+	return rowSpace.OrthogonalComplement()
+}
+
+// Image return the image of f, the span of the images of the base vectors.
+func (f *GF2LinearMap) Image() *GF2Span {
+	image := f.to.NewGF2Span()
+	for i := uint(1); i <= f.from.dim; i++ {
+		image.add(f.Apply(f.from.GF2BaseVector(i)))
+	}
+	return image
+}
+
+// IsInjective return true if f maps different vectors to different images.
+func (f *GF2LinearMap) IsInjective() bool {
+	return f.Rank() == f.from.dim
+}
+
+// IsSurjective return true if each vector of the codomain is an image of f.
+func (f *GF2LinearMap) IsSurjective() bool {
+	return f.Rank() == f.to.dim
+}
+
+// IsBijective return true if f is injective and surjective, it is invertible.
+func (f *GF2LinearMap) IsBijective() bool {
+	return f.from.dim == f.to.dim && f.IsInjective()
+}
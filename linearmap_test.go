@@ -0,0 +1,156 @@
+// Ralf Poeppel, 2026
+
+package gf2vs
+
+import (
+	"errors"
+	"math/big"
+	"math/rand/v2"
+	"testing"
+)
+
+// bitMatrix return a BitMatrix of the given rows.
+func bitMatrix(rows ...int64) BitMatrix {
+	m := make(BitMatrix, len(rows))
+	for i, r := range rows {
+		m[i] = big.NewInt(r)
+	}
+	return m
+}
+
+func TestGF2LinearMap(t *testing.T) {
+	cases := []struct {
+		from, to uint
+		m        BitMatrix
+		apply    [][2]uint // argument and image
+		rank     uint
+		kernel   string
+		image    string
+		inj, sur bool
+	}{
+		{3, 3, bitMatrix(0b100, 0b010, 0b001),
+			[][2]uint{{0b110, 0b110}, {0b001, 0b001}}, 3,
+			"GF(2)span{3: []}", "GF(2)span{3: [100 010 001]}", true, true},
+		// projection to the two least significant coordinates
+		{3, 2, bitMatrix(0b010, 0b001),
+			[][2]uint{{0b110, 0b10}, {0b101, 0b01}}, 2,
+			"GF(2)span{3: [100]}", "GF(2)span{2: [10 01]}", false, true},
+		// embedding into the two most significant coordinates
+		{2, 3, bitMatrix(0b10, 0b01, 0b00),
+			[][2]uint{{0b11, 0b110}, {0b01, 0b010}}, 2,
+			"GF(2)span{2: []}", "GF(2)span{3: [100 010]}", true, false},
+		// parity
+		{3, 1, bitMatrix(0b111),
+			[][2]uint{{0b110, 0}, {0b111, 1}}, 1,
+			"GF(2)span{3: [101 011]}", "GF(2)span{1: [1]}", false, true},
+		// sum of neighbors
+		{3, 3, bitMatrix(0b110, 0b011, 0b101),
+			[][2]uint{{0b100, 0b101}, {0b111, 0b000}}, 2,
+			"GF(2)span{3: [111]}", "GF(2)span{3: [101 011]}", false, false},
+	}
+	for _, c := range cases {
+		from := NewGF2VectorSpace(c.from)
+		to := NewGF2VectorSpace(c.to)
+		f := NewGF2LinearMap(from, to, c.m)
+		for _, a := range c.apply {
+			v := from.NewGF2Vector(a[0])
+			if got := f.Apply(v); got.Val() != a[1] || got.sp.dim != c.to {
+				t.Errorf("%v.Apply(%v) = %v, want %v", f, v, got, to.NewGF2Vector(a[1]))
+			}
+		}
+		if got := f.Rank(); got != c.rank {
+			t.Errorf("%v.Rank() = %v, want %v", f, got, c.rank)
+		}
+		if got := f.Kernel().String(); got != c.kernel {
+			t.Errorf("%v.Kernel() = %v, want %v", f, got, c.kernel)
+		}
+		if got := f.Image().String(); got != c.image {
+			t.Errorf("%v.Image() = %v, want %v", f, got, c.image)
+		}
+		if got := f.Kernel().Dim() + f.Image().Dim(); got != c.from {
+			t.Errorf("%v: dim of kernel and image = %v, want %v", f, got, c.from)
+		}
+		if f.IsInjective() != c.inj || f.IsSurjective() != c.sur || f.IsBijective() != (c.inj && c.sur) {
+			t.Errorf("%v.IsInjective(), IsSurjective(), IsBijective() = %v, %v, %v, want %v, %v, %v",
+				f, f.IsInjective(), f.IsSurjective(), f.IsBijective(), c.inj, c.sur, c.inj && c.sur)
+		}
+		// the map of the images of the base vectors is the same
+		images := make([]*GF2Vector, c.from)
+		for i := range images {
+			images[i] = f.Apply(from.GF2BaseVector(uint(i) + 1))
+		}
+		g := NewGF2LinearMapImages(from, images)
+		if got := g.Matrix(); got.Cmp(&c.m) != 0 {
+			t.Errorf("NewGF2LinearMapImages(%v) = %v, want %v", images, g, f)
+		}
+	}
+}
+
+func TestGF2LinearMapCompose(t *testing.T) {
+	r := rand.New(rand.NewPCG(7, 8))
+	dims := []uint{3, 70, 5, 130}
+	sp := make([]*GF2VectorSpace, len(dims))
+	for i, d := range dims {
+		sp[i] = NewGF2VectorSpace(d)
+	}
+	// random maps sp[0] -> sp[1] -> sp[2] -> sp[3]
+	maps := make([]*GF2LinearMap, len(dims)-1)
+	for i := range maps {
+		images := make([]*GF2Vector, dims[i])
+		for j := range images {
+			images[j] = randomVector(r, sp[i+1], 32)
+		}
+		maps[i] = NewGF2LinearMapImages(sp[i], images)
+	}
+	h := maps[2].Compose(maps[1]).Compose(maps[0])
+	h2 := maps[2].Compose(maps[1].Compose(maps[0]))
+	for range 20 {
+		v := randomVector(r, sp[0], 32)
+		want := maps[2].Apply(maps[1].Apply(maps[0].Apply(v)))
+		if got := h.Apply(v); got.String() != want.String() {
+			t.Errorf("(h∘g∘f).Apply(%v) = %v, want %v", v, got, want)
+		}
+		if got := h2.Apply(v); got.String() != want.String() {
+			t.Errorf("(h∘(g∘f)).Apply(%v) = %v, want %v", v, got, want)
+		}
+	}
+	for _, b := range maps[1].Kernel().Basis() {
+		if !maps[1].Apply(b).IsZeros() {
+			t.Errorf("%v of kernel has image %v", b, maps[1].Apply(b))
+		}
+	}
+
+	want := "Compose: incompatible vector spaces: z.dim = 3 != 5 = y.dim"
+	defer func() {
+		if r := recover(); r != want {
+			t.Errorf("Compose == Panic(%v), want Panic(%v)", r, want)
+		}
+	}()
+	maps[0].Compose(maps[1])
+}
+
+func TestNewGF2LinearMapErr(t *testing.T) {
+	sp2 := NewGF2VectorSpace(2)
+	sp3 := NewGF2VectorSpace(3)
+	cases := []struct {
+		m    BitMatrix
+		want string
+	}{
+		{bitMatrix(1, 2), "NewGF2LinearMap(from, to, m): len(m) = 2 != 3 = to.dim"},
+		{bitMatrix(1, 2, 4), "NewGF2LinearMap(from, to, m): row 2 = 4 has more bits than from.dim = 2"},
+		{bitMatrix(1, -1, 0), "NewGF2LinearMap(from, to, m): row 1 = -1 has more bits than from.dim = 2"},
+	}
+	for _, c := range cases {
+		_, err := NewGF2LinearMapErr(sp2, sp3, c.m)
+		if err == nil || err.Error() != c.want || !errors.Is(err, ErrDimensionMismatch) {
+			t.Errorf("NewGF2LinearMapErr(2, 3, %v) = %v, want %v", c.m, err, c.want)
+		}
+	}
+	// the matrix is copied
+	m := bitMatrix(1, 2, 3)
+	f := NewGF2LinearMap(sp2, sp3, m)
+	m[0].SetInt64(0)
+	if got := f.Apply(sp2.NewGF2Vector(1)).String(); got != "101" {
+		t.Errorf("%v.Apply(01) = %v, want 101", f, got)
+	}
+}
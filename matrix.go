@@ -0,0 +1,473 @@
+// Ralf Poeppel, 2026
+//
+// This file implements bit matrices with an explicit count of rows and
+// columns. A BitMatrix knows its count of columns only from the BitLen of
+// its rows, so columns of zeros on the left get lost. A SizedBitMatrix
+// keeps them. Column 0 is the leftmost column, the most significant bit
+// Cols-1 of a row, as in Text and the solutions of XorSatSolve.
+
+package gf2vs
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"math/bits"
+	"strings"
+)
+
+// SizedBitMatrix is a matrix of Rows rows and Cols columns, the rows are
+// kept as a BitMatrix of rows of at most Cols bits.
+type SizedBitMatrix struct {
+	rows BitMatrix
+	cols int
+}
+
+// NewBitMatrix create the zero matrix of r rows and c columns.
+// Panic if r or c is negative.
+func NewBitMatrix(r, c int) *SizedBitMatrix {
+	if r < 0 || c < 0 {
+		panic(fmt.Sprintf("NewBitMatrix(r, c): r = %v or c = %v is negative", r, c))
+	}
+	bm := make(BitMatrix, r)
+	for i := range bm {
+		bm[i] = new(big.Int)
+	}
+	return &SizedBitMatrix{bm, c}
+}
+
+// Identity create the identity matrix of n rows and columns.
+// Panic if n is negative.
+func Identity(n int) *SizedBitMatrix {
+	m := NewBitMatrix(n, n)
+	for i, row := range m.rows {
+		row.SetBit(row, n-1-i, 1)
+	}
+	return m
+}
+
+// FromRows create the matrix with the rows given by the vectors rows,
+// the coordinate k of a vector is the column Cols-k, so the row is written
+// as the String of the vector. The count of columns is the dimension of
+// the vectors, it is 0 if there are no rows.
+// Panic if x_i and x_j are of vector spaces of different dimension.
+func FromRows(rows []*GF2Vector) *SizedBitMatrix {
+	if len(rows) == 0 {
+		return NewBitMatrix(0, 0)
+	}
+	mustSameSpace("FromRows", rows)
+	bm := make(BitMatrix, len(rows))
+	for i, v := range rows {
+		bm[i] = intOf(v)
+	}
+	return &SizedBitMatrix{bm, int(rows[0].sp.dim)}
+}
+
+// FromStrings create the matrix with the rows given by the binary strings s
+// of equal length, e.g. FromStrings([]string{"010", "001"}).
+// Panic if a string is no binary string of the length of s[0],
+// it is FromStringsErr panicking with its error.
+func FromStrings(s []string) *SizedBitMatrix {
+	m, err := FromStringsErr(s)
+	if err != nil {
+		panic(err.Error())
+	}
+	return m
+}
+
+// FromStringsErr create the matrix with the rows given by the binary strings s
+// of equal length, e.g. FromStringsErr([]string{"010", "001"}).
+// Return an error wrapping ErrInvalidEncoding if a string is no binary
+// string of the length of s[0].
+func FromStringsErr(s []string) (*SizedBitMatrix, error) {
+	if len(s) == 0 {
+		return NewBitMatrix(0, 0), nil
+	}
+	c := len(s[0])
+	bm := make(BitMatrix, len(s))
+	for i, r := range s {
+		x, ok := intFromText(r, c)
+		if !ok {
+			return nil, invalid("FromStrings(s)", "s[%v] = %q is no binary string of %v digits", i, r, c)
+		}
+		bm[i] = x
+	}
+	return &SizedBitMatrix{bm, c}, nil
+}
+
+// FromBitMatrix create the matrix of the rows of bm and c columns,
+// the rows are copied.
+// Panic if c is negative or a row of bm is nil, negative or has more than
+// c bits, it is FromBitMatrixErr panicking with its error.
+func FromBitMatrix(bm BitMatrix, c int) *SizedBitMatrix {
+	m, err := FromBitMatrixErr(bm, c)
+	if err != nil {
+		panic(err.Error())
+	}
+	return m
+}
+
+// FromBitMatrixErr create the matrix of the rows of bm and c columns,
+// the rows are copied.
+// Return an error wrapping ErrValueOutOfRange if c is negative or a row of bm
+// is nil, negative or has more than c bits.
+func FromBitMatrixErr(bm BitMatrix, c int) (*SizedBitMatrix, error) {
+	const op = "FromBitMatrix(bm, c)"
+	if c < 0 {
+		return nil, outOfRange(op, "c = %v is negative", c)
+	}
+	if err := bm.checkRows(op, c); err != nil {
+		return nil, err
+	}
+	return &SizedBitMatrix{*new(BitMatrix).Set(&bm), c}, nil
+}
+
+// checkRows return an error wrapping ErrValueOutOfRange if a row of bm is
+// nil, negative or has more than c bits.
+func (bm *BitMatrix) checkRows(op string, c int) error {
+	for i, row := range *bm {
+		if row == nil || row.Sign() < 0 || row.BitLen() > c {
+			return outOfRange(op, "row %v = %v is no row of %v bits", i, row, c)
+		}
+	}
+	return nil
+}
+
+// Rows return the count of rows of m.
+func (m *SizedBitMatrix) Rows() int {
+	return len(m.rows)
+}
+
+// Cols return the count of columns of m.
+func (m *SizedBitMatrix) Cols() int {
+	return m.cols
+}
+
+// BitMatrix return a copy of the rows of m, column 0 is bit Cols-1 of a row.
+func (m *SizedBitMatrix) BitMatrix() BitMatrix {
+	return *new(BitMatrix).Set(&m.rows)
+}
+
+// String return the rows of m as binary strings of Cols digits,
+// each followed by a newline.
+func (m *SizedBitMatrix) String() string {
+	var sb strings.Builder
+	for _, row := range m.rows {
+		sb.WriteString(binaryText(row, m.cols))
+		sb.WriteByte('\n')
+	}
+	return sb.String()
+}
+
+// Copy return a deep copy of m.
+func (m *SizedBitMatrix) Copy() *SizedBitMatrix {
+	return &SizedBitMatrix{*new(BitMatrix).Set(&m.rows), m.cols}
+}
+
+// Equal return true if m and n have the same size and bits.
+func (m *SizedBitMatrix) Equal(n *SizedBitMatrix) bool {
+	return m.cols == n.cols && m.rows.Cmp(&n.rows) == 0
+}
+
+// check panic if row i or column j is not in m.
+func (m *SizedBitMatrix) check(op string, i, j int) {
+	if i < 0 || i >= m.Rows() || j < 0 || j >= m.cols {
+		panic(fmt.Sprintf("%v(i, j): (%v, %v) not in [0, %v) x [0, %v)", op, i, j, m.Rows(), m.cols))
+	}
+}
+
+// Bit return the bit of row i and column j, the indexes start at 0.
+// Panic if row i or column j is not in m.
+func (m *SizedBitMatrix) Bit(i, j int) uint {
+	m.check("Bit", i, j)
+	return m.rows[i].Bit(m.cols - 1 - j)
+}
+
+// SetBit set the bit of row i and column j to b and return m,
+// the indexes start at 0, b must be 0 or 1.
+// Panic if row i or column j is not in m.
+func (m *SizedBitMatrix) SetBit(i, j int, b uint) *SizedBitMatrix {
+	m.check("SetBit", i, j)
+	row := m.rows[i]
+	row.SetBit(row, m.cols-1-j, b&1)
+	return m
+}
+
+// Row return the row i of m as vector of dimension Cols, see FromRows.
+// Panic if row i is not in m or m has no columns.
+func (m *SizedBitMatrix) Row(i int) *GF2Vector {
+	if i < 0 || i >= m.Rows() {
+		panic(fmt.Sprintf("Row(i): i = %v not in [0, %v)", i, m.Rows()))
+	}
+	return NewGF2VectorSpace(uint(m.cols)).vectorOf(m.rows[i])
+}
+
+// RowVectors return the rows of m as vectors of dimension Cols, see FromRows.
+// Panic if m has no columns.
+func (m *SizedBitMatrix) RowVectors() []*GF2Vector {
+	sp := NewGF2VectorSpace(uint(m.cols))
+	vs := make([]*GF2Vector, m.Rows())
+	for i, row := range m.rows {
+		vs[i] = sp.vectorOf(row)
+	}
+	return vs
+}
+
+// RowReducedEcholonForm convert m to row reduced echolon form with mr right
+// sides as BitMatrix.RowReducedEcholonForm, but the zero rows are kept at the
+// bottom, so Rows and Cols of m are not changed.
+func (m *SizedBitMatrix) RowReducedEcholonForm(mr int) (rank int, ok bool) {
+	bm := m.rows
+	return bm.RowReducedEcholonForm(mr)
+}
+
+// SolutionFromRref determine the solution of an extended coefficient matrix
+// in row reduced echolon form with mr right sides as BitMatrix.SolutionFromRref,
+// the row length is Cols, so variables of zero columns on the left are kept.
+// The zero rows at the bottom are ignored.
+func (m *SizedBitMatrix) SolutionFromRref(mr int) ([]int, error) {
+	bm := m.rows
+	for len(bm) > 0 && bm[len(bm)-1].Sign() == 0 {
+		bm = bm[:len(bm)-1]
+	}
+	return bm.solutionFromRref(mr, m.cols)
+}
+
+// XorSatSolve return the solution of a xor-sat problem given as m with mr
+// right sides as BitMatrix.XorSatSolve, the row length is Cols.
+// m is converted to row reduced echolon form, Rows and Cols are not changed.
+func (m *SizedBitMatrix) XorSatSolve(mr int) ([]int, int, error) {
+	rank, ok := m.RowReducedEcholonForm(mr)
+	if !ok {
+		return nil, 0, &XorSatSolveError{"Contradiction of equations of BitMatrix"}
+	}
+	sol, err := m.SolutionFromRref(mr)
+	return sol, rank, err
+}
+
+// Transpose return the transposed matrix of m, row i of m is column i
+// of the transposed matrix.
+func (m *SizedBitMatrix) Transpose() *SizedBitMatrix {
+	r := m.Rows()
+	tw := make([][]big.Word, m.cols)
+	n := (r + bits.UintSize - 1) / bits.UintSize
+	for i, row := range m.rows {
+		pos := r - 1 - i // bit of column i in the rows of the transposed matrix
+		for j, x := range row.Bits() {
+			for ; x != 0; x &= x - 1 {
+				c := m.cols - 1 - (j*bits.UintSize + bits.TrailingZeros(uint(x)))
+				if tw[c] == nil {
+					tw[c] = make([]big.Word, n)
+				}
+				tw[c][pos/bits.UintSize] |= 1 << (pos % bits.UintSize)
+			}
+		}
+	}
+	t := make(BitMatrix, m.cols)
+	for c, w := range tw {
+		t[c] = new(big.Int).SetBits(w)
+	}
+	return &SizedBitMatrix{t, r}
+}
+
+// rank return the rank of m, the count of linearly independent rows.
+func (m *SizedBitMatrix) rank() int {
+	c := m.Copy()
+	rank, _ := c.RowReducedEcholonForm(0)
+	return rank
+}
+
+// square return an error wrapping ErrDimensionMismatch if m is not square.
+func (m *SizedBitMatrix) square(op string) error {
+	if m.Rows() != m.cols {
+		return &VectorSpaceError{op, fmt.Sprintf("matrix of %v rows and %v columns is not square", m.Rows(), m.cols), ErrDimensionMismatch}
+	}
+	return nil
+}
+
+// IsInvertible return true if m is square and of full rank.
+func (m *SizedBitMatrix) IsInvertible() bool {
+	return m.Rows() == m.cols && m.rank() == m.cols
+}
+
+// Det return the determinant of m, 1 if m is invertible else 0.
+// Panic if m is not square, it is DetErr panicking with its error.
+func (m *SizedBitMatrix) Det() uint {
+	d, err := m.DetErr()
+	if err != nil {
+		panic(err.Error())
+	}
+	return d
+}
+
+// DetErr return the determinant of m, 1 if m is invertible else 0.
+// Return an error wrapping ErrDimensionMismatch if m is not square.
+func (m *SizedBitMatrix) DetErr() (uint, error) {
+	if err := m.square("Det()"); err != nil {
+		return 0, err
+	}
+	if m.rank() == m.cols {
+		return 1, nil
+	}
+	return 0, nil
+}
+
+// Inverse return the inverse of m, Mul(m, Inverse()) is the identity.
+// Gauss–Jordan elimination of [m | I] by RowReducedEcholonForm with
+// the n right columns of I results in [I | m^-1].
+// Return an error wrapping ErrDimensionMismatch if m is not square,
+// or wrapping ErrSingularMatrix if m is not invertible.
+func (m *SizedBitMatrix) Inverse() (*SizedBitMatrix, error) {
+	const op = "Inverse()"
+	if err := m.square(op); err != nil {
+		return nil, err
+	}
+	n := m.cols
+	aug := make(BitMatrix, n)
+	for i, row := range m.rows {
+		aug[i] = new(big.Int).Lsh(row, uint(n))
+		aug[i].SetBit(aug[i], n-1-i, 1)
+	}
+	aug.RowReducedEcholonForm(n)
+	// the rows are sorted by the pivots descending, the left side is the
+	// identity if each pivot is left
+	left := 0
+	for _, row := range aug {
+		if row.BitLen() > n {
+			left++
+		}
+	}
+	if left < n {
+		return nil, &VectorSpaceError{op, fmt.Sprintf("matrix of rank %v < %v is singular", left, n), ErrSingularMatrix}
+	}
+	mask := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), uint(n)), big.NewInt(1))
+	inv := make(BitMatrix, n)
+	for i, row := range aug {
+		inv[i] = new(big.Int).And(row, mask)
+	}
+	return &SizedBitMatrix{inv, n}, nil
+}
+
+// Kernel return a basis of the null space of m as the rows of a matrix of
+// Cols columns, the vectors x with m.MulVec(x) = 0, so Mul(m, Kernel().Transpose())
+// is zero. RowVectors return the basis as vectors. There is a vector for
+// each free column of the row reduced echolon form of m, ascending by the
+// columns, it has the bit of its free column set and the bits of the pivot
+// columns, which solve the equations of the rows.
+// The kernel of a generator matrix of a linear code is a parity check matrix.
+func (m *SizedBitMatrix) Kernel() *SizedBitMatrix {
+	rref := m.Copy()
+	rank, _ := rref.RowReducedEcholonForm(0)
+	rows := rref.rows[:rank]
+	pivot := make([]bool, m.cols)
+	for _, row := range rows {
+		pivot[row.BitLen()-1] = true
+	}
+	var basis BitMatrix
+	for f := m.cols - 1; f >= 0; f-- {
+		if pivot[f] {
+			continue
+		}
+		// row r reads x_p + x_f * r_f = 0 of its pivot bit p
+		x := new(big.Int).SetBit(new(big.Int), f, 1)
+		for _, row := range rows {
+			if row.Bit(f) == 1 {
+				x.SetBit(x, row.BitLen()-1, 1)
+			}
+		}
+		basis = append(basis, x)
+	}
+	return &SizedBitMatrix{basis, m.cols}
+}
+
+// LeftKernel return a basis of the left null space of m as the rows of a
+// matrix of Rows columns, the vectors y with y m = 0, so Mul(LeftKernel(), m)
+// is zero. It is the Kernel of the transposed matrix, the rows of a basis
+// are the linear dependencies of the rows of m.
+func (m *SizedBitMatrix) LeftKernel() *SizedBitMatrix {
+	return m.Transpose().Kernel()
+}
+
+// The BitMatrix forms of the matrix operations, the count of columns of a
+// BitMatrix is the largest BitLen of its rows, see SizedBitMatrix.
+
+// square return bm as matrix of len(bm) columns, it shares the rows of bm.
+// Return an error wrapping ErrValueOutOfRange if a row of bm is nil or
+// negative, or wrapping ErrDimensionMismatch if a row of bm has more than
+// len(bm) bits, so bm is not square.
+func (bm *BitMatrix) square(op string) (*SizedBitMatrix, error) {
+	n := len(*bm)
+	if err := bm.checkRows(op, math.MaxInt); err != nil {
+		return nil, err
+	}
+	if c := bm.cols(0); c > n {
+		return nil, &VectorSpaceError{op, fmt.Sprintf("matrix of %v rows and %v columns is not square", n, c), ErrDimensionMismatch}
+	}
+	return &SizedBitMatrix{*bm, n}, nil
+}
+
+// Transpose return the transposed matrix of bm as SizedBitMatrix.Transpose,
+// it has a row for each column up to the largest BitLen of the rows of bm,
+// the rows have len(bm) bits.
+// Panic if a row of bm is nil or negative.
+func (bm *BitMatrix) Transpose() BitMatrix {
+	if err := bm.checkRows("Transpose()", math.MaxInt); err != nil {
+		panic(err.Error())
+	}
+	return (&SizedBitMatrix{*bm, bm.cols(0)}).Transpose().rows
+}
+
+// IsInvertible return true if bm is square and of full rank, see BitMatrix.Inverse.
+// Panic if a row of bm is nil or negative.
+func (bm *BitMatrix) IsInvertible() bool {
+	m, err := bm.square("IsInvertible()")
+	if errors.Is(err, ErrDimensionMismatch) {
+		return false
+	}
+	if err != nil {
+		panic(err.Error())
+	}
+	return m.IsInvertible()
+}
+
+// Det return the determinant of bm, 1 if bm is invertible else 0,
+// see BitMatrix.Inverse.
+// Panic if bm is not square or a row of bm is nil or negative,
+// it is DetErr panicking with its error.
+func (bm *BitMatrix) Det() uint {
+	d, err := bm.DetErr()
+	if err != nil {
+		panic(err.Error())
+	}
+	return d
+}
+
+// DetErr return the determinant of bm, 1 if bm is invertible else 0,
+// see BitMatrix.Inverse.
+// Return an error wrapping ErrDimensionMismatch if bm is not square,
+// or wrapping ErrValueOutOfRange if a row of bm is nil or negative.
+func (bm *BitMatrix) DetErr() (uint, error) {
+	m, err := bm.square("Det()")
+	if err != nil {
+		return 0, err
+	}
+	return m.DetErr()
+}
+
+// Inverse return the inverse of bm as SizedBitMatrix.Inverse, bm is square
+// if its rows have at most len(bm) bits.
+// Return an error wrapping ErrDimensionMismatch if bm is not square,
+// wrapping ErrValueOutOfRange if a row of bm is nil or negative,
+// or wrapping ErrSingularMatrix if bm is not invertible.
+func (bm *BitMatrix) Inverse() (BitMatrix, error) {
+	m, err := bm.square("Inverse()")
+	if err != nil {
+		return nil, err
+	}
+	inv, err := m.Inverse()
+	if err != nil {
+		return nil, err
+	}
+	return inv.rows, nil
+}
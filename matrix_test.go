@@ -0,0 +1,313 @@
+// Ralf Poeppel, 2026
+
+package gf2vs
+
+import (
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"testing"
+)
+
+func TestSizedBitMatrixConstructors(t *testing.T) {
+	sp := NewGF2VectorSpace(4)
+	cases := []struct {
+		m    *SizedBitMatrix
+		rows int
+		cols int
+		want string
+	}{
+		{NewBitMatrix(0, 0), 0, 0, ""},
+		{NewBitMatrix(2, 3), 2, 3, "000\n000\n"},
+		{NewBitMatrix(0, 3), 0, 3, ""},
+		{Identity(3), 3, 3, "100\n010\n001\n"},
+		{FromRows(vectors(sp, 0b0010, 0b0000, 0b1001)), 3, 4, "0010\n0000\n1001\n"},
+		{FromRows(nil), 0, 0, ""},
+		{FromStrings([]string{"0010", "0000", "1001"}), 3, 4, "0010\n0000\n1001\n"},
+		{FromStrings([]string{"", ""}), 2, 0, "\n\n"},
+	}
+	for _, c := range cases {
+		if c.m.Rows() != c.rows || c.m.Cols() != c.cols || c.m.String() != c.want {
+			t.Errorf("%q: Rows() = %v, Cols = %v, want %v, %v, %q", c.m, c.m.Rows(), c.m.Cols(), c.rows, c.cols, c.want)
+		}
+	}
+}
+
+func TestFromStringsErr(t *testing.T) {
+	for _, s := range [][]string{{"01", "1"}, {"012"}, {"01", "0x"}} {
+		if _, err := FromStringsErr(s); !errors.Is(err, ErrInvalidEncoding) {
+			t.Errorf("FromStringsErr(%q) error = %v, want %v", s, err, ErrInvalidEncoding)
+		}
+	}
+}
+
+func TestFromBitMatrix(t *testing.T) {
+	bm := bitMatrix(0, 5, 2)
+	m := FromBitMatrix(bm, 4)
+	if m.Rows() != 3 || m.Cols() != 4 || m.String() != "0000\n0101\n0010\n" {
+		t.Errorf("FromBitMatrix(%v, 4) = %q", bm, m)
+	}
+	// the rows are copied in and out
+	bm[1].SetInt64(1)
+	m.BitMatrix()[2].SetInt64(1)
+	if m.String() != "0000\n0101\n0010\n" {
+		t.Errorf("FromBitMatrix(bm, 4) changed to %q", m)
+	}
+	cases := []struct {
+		bm   BitMatrix
+		c    int
+		want string
+	}{
+		{bitMatrix(1, 6), 2, "FromBitMatrix(bm, c): row 1 = 6 is no row of 2 bits"},
+		{BitMatrix{nil}, 3, "FromBitMatrix(bm, c): row 0 = <nil> is no row of 3 bits"},
+		{bitMatrix(-1), 3, "FromBitMatrix(bm, c): row 0 = -1 is no row of 3 bits"},
+		{bitMatrix(1), -1, "FromBitMatrix(bm, c): c = -1 is negative"},
+	}
+	for _, c := range cases {
+		if _, err := FromBitMatrixErr(c.bm, c.c); !errors.Is(err, ErrValueOutOfRange) || err.Error() != c.want {
+			t.Errorf("FromBitMatrixErr(%v, %v) error = %v, want %v", c.bm, c.c, err, c.want)
+		}
+		func() {
+			defer func() {
+				if got := recover(); got != c.want {
+					t.Errorf("FromBitMatrix(%v, %v) panic %v, want %v", c.bm, c.c, got, c.want)
+				}
+			}()
+			FromBitMatrix(c.bm, c.c)
+		}()
+	}
+}
+
+func TestSizedBitMatrixBits(t *testing.T) {
+	m := NewBitMatrix(2, 70)
+	m.SetBit(0, 0, 1).SetBit(1, 69, 1).SetBit(1, 3, 1).SetBit(1, 3, 0)
+	if m.Bit(0, 0) != 1 || m.Bit(1, 69) != 1 || m.Bit(1, 3) != 0 || m.Bit(0, 69) != 0 {
+		t.Errorf("Bit of %v", m)
+	}
+	c := m.Copy()
+	c.SetBit(0, 1, 1)
+	if !m.Equal(FromRows(m.RowVectors())) || m.Equal(c) || m.Row(0).Bit(70) != 1 {
+		t.Errorf("Copy, Equal, RowVectors of %v", m)
+	}
+	if got := fmt.Sprint(Identity(2)); got != "10\n01\n" {
+		t.Errorf("Sprint(Identity(2)) = %q", got)
+	}
+	if got := fmt.Sprintf("%+.1v", FromStrings([]string{"0011", "0100"})); got != "001|1\n010|0\n" {
+		t.Errorf("%%+.1v = %q", got)
+	}
+	defer func() {
+		if got := recover(); got != "Bit(i, j): (0, 70) not in [0, 2) x [0, 70)" {
+			t.Errorf("Bit(0, 70) panic %v", got)
+		}
+	}()
+	m.Bit(0, 70)
+}
+
+func TestSizedBitMatrixSolve(t *testing.T) {
+	cases := []struct {
+		in   []string
+		mr   int
+		want string
+		werr bool
+	}{
+		// the variable of the leading zero column is kept
+		{[]string{"01001", "00100", "00011"}, 1, "[-1 1 0 1]", true},
+		{[]string{"00110", "01101", "00011"}, 1, "[-1 0 1 1]", true},
+		{[]string{"10001", "00110", "01101", "00011"}, 1, "[1 0 1 1]", false},
+		// the zero row of the equal rows is kept
+		{[]string{"00110", "01101", "00011", "00110"}, 1, "[-1 0 1 1]", true},
+		{[]string{"0010010", "0001001"}, 2, "[-1 -1 2 1 -1]", true},
+		{[]string{"0000011", "0010010"}, 2, "[]", true},
+	}
+	for _, c := range cases {
+		m := FromStrings(c.in)
+		got, _, err := m.XorSatSolve(c.mr)
+		if fmt.Sprint(got) != c.want || (err != nil) != c.werr {
+			t.Errorf("%q.XorSatSolve(%v) = %v, %v, want %v", c.in, c.mr, got, err, c.want)
+		}
+		if m.Rows() != len(c.in) || m.Cols() != len(c.in[0]) {
+			t.Errorf("%q.XorSatSolve(%v) changed the size to %v x %v", c.in, c.mr, m.Rows(), m.Cols())
+		}
+	}
+	m := FromStrings([]string{"110", "110", "011"})
+	if rank, ok := m.RowReducedEcholonForm(0); rank != 2 || !ok || m.String() != "101\n011\n000\n" {
+		t.Errorf("RowReducedEcholonForm(0) = %v, %v, %q", rank, ok, m)
+	}
+}
+
+func TestTranspose(t *testing.T) {
+	cases := []struct {
+		in   []string
+		want string
+	}{
+		{[]string{"110", "011"}, "10\n11\n01\n"},
+		{[]string{"0000", "0010"}, "00\n00\n01\n00\n"},
+		{[]string{"1"}, "1\n"},
+	}
+	for _, c := range cases {
+		if got := FromStrings(c.in).Transpose().String(); got != c.want {
+			t.Errorf("%q.Transpose() = %q, want %q", c.in, got, c.want)
+		}
+	}
+	r := rand.New(rand.NewPCG(1, 2))
+	for _, d := range [][3]int{{3, 70, 5}, {130, 65, 64}, {1, 200, 129}} {
+		a, b := randomMatrix(r, d[0], d[1]), randomMatrix(r, d[1], d[2])
+		if !a.Transpose().Transpose().Equal(a) {
+			t.Errorf("Transpose of Transpose of %v x %v is not the matrix", d[0], d[1])
+		}
+		if !Mul(a, b).Transpose().Equal(Mul(b.Transpose(), a.Transpose())) {
+			t.Errorf("(ab)^T != b^T a^T of %v", d)
+		}
+	}
+}
+
+func TestInverse(t *testing.T) {
+	cases := []struct {
+		in   []string
+		want string
+		det  uint
+	}{
+		{[]string{"10", "11"}, "10\n11\n", 1},
+		{[]string{"011", "101", "001"}, "011\n101\n001\n", 1},
+		{[]string{"0100", "0010", "0001", "1000"}, "0001\n1000\n0100\n0010\n", 1},
+		{[]string{"110", "011", "101"}, "", 0},
+		{[]string{"00", "01"}, "", 0},
+		{[]string{}, "", 1},
+	}
+	for _, c := range cases {
+		m := FromStrings(c.in)
+		inv, err := m.Inverse()
+		if m.Det() != c.det || m.IsInvertible() != (c.det == 1) {
+			t.Errorf("%q.Det() = %v, IsInvertible() = %v, want %v", c.in, m.Det(), m.IsInvertible(), c.det)
+		}
+		// the BitMatrix forms of len(in) columns
+		bm := m.BitMatrix()
+		binv, berr := bm.Inverse()
+		if bm.Det() != c.det || bm.IsInvertible() != (c.det == 1) || (berr == nil) != (c.det == 1) ||
+			berr == nil && FromBitMatrix(binv, len(c.in)).String() != c.want {
+			t.Errorf("BitMatrix %q: Det() = %v, Inverse() = %v, %v, want %v, %q", c.in, bm.Det(), binv, berr, c.det, c.want)
+		}
+		if c.det == 0 {
+			if !errors.Is(err, ErrSingularMatrix) {
+				t.Errorf("%q.Inverse() error = %v, want %v", c.in, err, ErrSingularMatrix)
+			}
+			continue
+		}
+		if err != nil || inv.String() != c.want {
+			t.Errorf("%q.Inverse() = %q, %v, want %q", c.in, inv, err, c.want)
+		}
+	}
+	if _, err := FromStrings([]string{"10"}).Inverse(); !errors.Is(err, ErrDimensionMismatch) {
+		t.Errorf("Inverse() of 1 x 2 error = %v, want %v", err, ErrDimensionMismatch)
+	}
+	if _, err := FromStrings([]string{"10"}).DetErr(); !errors.Is(err, ErrDimensionMismatch) {
+		t.Errorf("DetErr() of 1 x 2 error = %v, want %v", err, ErrDimensionMismatch)
+	}
+	if FromStrings([]string{"10"}).IsInvertible() {
+		t.Errorf("IsInvertible() of 1 x 2 is true")
+	}
+	r := rand.New(rand.NewPCG(1, 2))
+	for _, n := range []int{5, 64, 100, 200} {
+		for range 4 {
+			m := randomMatrix(r, n, n)
+			inv, err := m.Inverse()
+			if err != nil {
+				if m.Det() != 0 {
+					t.Errorf("Inverse() of %v x %v: %v, Det() = 1", n, n, err)
+				}
+				continue
+			}
+			if id := Identity(n); !Mul(m, inv).Equal(id) || !Mul(inv, m).Equal(id) {
+				t.Errorf("Inverse() of %v x %v is no inverse", n, n)
+			}
+		}
+	}
+	defer func() {
+		if got := recover(); got != "Det(): matrix of 1 rows and 2 columns is not square" {
+			t.Errorf("Det() panic %v", got)
+		}
+	}()
+	FromStrings([]string{"10"}).Det()
+}
+
+func TestBitMatrixInverse(t *testing.T) {
+	in, want := bitMatrix(0b110, 0b011), bitMatrix(0b10, 0b11, 0b01)
+	if got := in.Transpose(); got.Cmp(&want) != 0 {
+		t.Errorf("%v.Transpose() = %v, want %v", in, got, want)
+	}
+	// the rows of 3 bits are no square matrix of 2 rows
+	bm := bitMatrix(0b100, 0b001)
+	if _, err := bm.Inverse(); !errors.Is(err, ErrDimensionMismatch) {
+		t.Errorf("%v.Inverse() error = %v, want %v", bm, err, ErrDimensionMismatch)
+	}
+	if _, err := bm.DetErr(); !errors.Is(err, ErrDimensionMismatch) || bm.IsInvertible() {
+		t.Errorf("%v.DetErr() error = %v, IsInvertible() = %v, want %v", bm, err, bm.IsInvertible(), ErrDimensionMismatch)
+	}
+	for _, bm := range []BitMatrix{{nil}, bitMatrix(-1)} {
+		if _, err := bm.Inverse(); !errors.Is(err, ErrValueOutOfRange) {
+			t.Errorf("%v.Inverse() error = %v, want %v", bm, err, ErrValueOutOfRange)
+		}
+	}
+	r := rand.New(rand.NewPCG(1, 2))
+	for _, n := range []int{5, 64, 100} {
+		m := randomMatrix(r, n, n)
+		bm := m.BitMatrix()
+		want, werr := m.Inverse()
+		got, err := bm.Inverse()
+		if (err == nil) != (werr == nil) || err == nil && got.Cmp(&want.rows) != 0 {
+			t.Errorf("Inverse() of %v x %v BitMatrix = %v, want the inverse of the SizedBitMatrix", n, n, err)
+		}
+		// the BitMatrix has no leading zero columns
+		tr, wt := bm.Transpose(), m.Transpose().rows
+		wt = wt[n-len(tr):]
+		if tr.Cmp(&wt) != 0 {
+			t.Errorf("Transpose() of %v x %v BitMatrix is not the transposed matrix", n, n)
+		}
+	}
+	defer func() {
+		if got := recover(); got != "Det(): matrix of 2 rows and 3 columns is not square" {
+			t.Errorf("Det() panic %v", got)
+		}
+	}()
+	bm.Det()
+}
+
+func TestKernel(t *testing.T) {
+	cases := []struct {
+		in   []string
+		want string
+		left string
+	}{
+		{[]string{"110", "011"}, "111\n", ""},
+		{[]string{"101", "101", "000"}, "010\n101\n", "110\n001\n"},
+		{[]string{"100", "010", "001"}, "", ""},
+		{[]string{"000", "000"}, "100\n010\n001\n", "10\n01\n"},
+		// generator matrix of the Hamming [7,4] code
+		{[]string{"1000110", "0100101", "0010011", "0001111"}, "1101100\n1011010\n0111001\n", ""},
+	}
+	for _, c := range cases {
+		m := FromStrings(c.in)
+		k, l := m.Kernel(), m.LeftKernel()
+		if k.String() != c.want || k.Cols() != m.Cols() || l.String() != c.left || l.Cols() != m.Rows() {
+			t.Errorf("%q.Kernel() = %q, LeftKernel() = %q, want %q, %q", c.in, k.String(), l.String(), c.want, c.left)
+		}
+	}
+	r := rand.New(rand.NewPCG(1, 2))
+	for _, d := range [][2]int{{3, 8}, {70, 130}, {130, 70}, {100, 100}} {
+		m := randomMatrix(r, d[0], d[1])
+		m.rows[0].Xor(m.rows[1], m.rows[2])
+		rank := m.rank()
+		k, l := m.Kernel(), m.LeftKernel()
+		if k.Rows() != m.Cols()-rank || k.rank() != k.Rows() || l.Rows() != m.Rows()-rank || l.rank() != l.Rows() {
+			t.Errorf("%v: rank %v, Kernel of %v rows, LeftKernel of %v rows", d, rank, k.Rows(), l.Rows())
+		}
+		for _, x := range k.RowVectors() {
+			if !m.MulVec(x).IsZeros() {
+				t.Errorf("%v: m.MulVec(%v) != 0", d, x)
+			}
+		}
+		if z := Mul(l, m); !z.Equal(NewBitMatrix(l.Rows(), m.Cols())) {
+			t.Errorf("%v: Mul(LeftKernel(), m) = %v", d, z)
+		}
+	}
+}
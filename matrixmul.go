@@ -0,0 +1,231 @@
+// Ralf Poeppel, 2026
+//
+// This file implements the product of bit matrices. The rows are packed into
+// words of a dense block, a row of the product is the xor of the rows of the
+// right factor selected by the bits of the row of the left factor.
+// Small products use this naive kernel, larger products the Method of Four
+// Russians (M4RM): the xors of 8 rows of the right factor are tabulated in
+// Gray code order, each byte of a row of the left factor selects one entry.
+// https://en.wikipedia.org/w/index.php?title=Method_of_Four_Russians&oldid=1259155720
+// Very large products are split into blocks by the Strassen–Winograd
+// recursion with 7 block products and 15 block sums, the matrices are padded
+// to dimensions divisible by the blocks of all levels.
+// https://en.wikipedia.org/w/index.php?title=Strassen_algorithm&oldid=1318651735
+
+package gf2vs
+
+import (
+	"fmt"
+	"math/big"
+	"math/bits"
+)
+
+// m4rmThreshold the count of rows and inner columns from which on M4RM is used.
+var m4rmThreshold = 64
+
+// strassenThreshold the least count of rows and columns of the blocks,
+// which are split by the Strassen–Winograd recursion.
+var strassenThreshold = 2048
+
+// m4rmBits the count of rows of a Gray code table of M4RM.
+const m4rmBits = 8
+
+// block is a dense matrix of rows rows of words words, a view on the words
+// w with stride words per row. Bit k of word j of a row is column j*UintSize+k.
+type block struct {
+	w      []uint
+	stride int
+	rows   int
+	words  int
+}
+
+// newBlock return the zero block of r rows and n words per row.
+func newBlock(r, n int) block {
+	return block{make([]uint, r*n), n, r, n}
+}
+
+// row return the words of row i of b.
+func (b block) row(i int) []uint {
+	return b.w[i*b.stride : i*b.stride+b.words]
+}
+
+// sub return the block of rows rows from row r0 and words words from word w0.
+func (b block) sub(r0, rows, w0, words int) block {
+	return block{b.w[r0*b.stride+w0:], b.stride, rows, words}
+}
+
+// quarters return the 2x2 blocks of b.
+func (b block) quarters() (b11, b12, b21, b22 block) {
+	r, n := b.rows/2, b.words/2
+	return b.sub(0, r, 0, n), b.sub(0, r, n, n), b.sub(r, r, 0, n), b.sub(r, r, n, n)
+}
+
+// xorBlock set z ^= x for each x of xs, the blocks are of equal size.
+func xorBlock(z block, xs ...block) {
+	for i := range z.rows {
+		zr := z.row(i)
+		for _, x := range xs {
+			xorInto(zr, x.row(i))
+		}
+	}
+}
+
+// sumBlock return a new block x_1 + ... + x_n.
+func sumBlock(xs ...block) block {
+	z := newBlock(xs[0].rows, xs[0].words)
+	xorBlock(z, xs...)
+	return z
+}
+
+// mulAddNaive set c ^= a * b row by row.
+func mulAddNaive(c, a, b block) {
+	for i := range a.rows {
+		cr := c.row(i)
+		for j, x := range a.row(i) {
+			for ; x != 0; x &= x - 1 {
+				xorInto(cr, b.row(j*bits.UintSize+bits.TrailingZeros(x)))
+			}
+		}
+	}
+}
+
+// mulAddM4RM set c ^= a * b by the Method of Four Russians.
+func mulAddM4RM(c, a, b block) {
+	const size = 1 << m4rmBits
+	table := newBlock(size, b.words)
+	for k := 0; k < a.words*bits.UintSize; k += m4rmBits {
+		// table row g of the Gray code g = i ^ i>>1 is the xor of the rows
+		// k + j of b of the bits j set in g
+		prev := table.row(0)
+		for i := 1; i < size; i++ {
+			g := i ^ i>>1
+			r := table.row(g)
+			copy(r, prev)
+			xorInto(r, b.row(k+bits.TrailingZeros(uint(i))))
+			prev = r
+		}
+		j, s := k/bits.UintSize, k%bits.UintSize
+		for i := range a.rows {
+			if x := a.w[i*a.stride+j] >> s & (size - 1); x != 0 {
+				xorInto(c.row(i), table.row(int(x)))
+			}
+		}
+	}
+}
+
+// mulAdd set c ^= a * b, the inner dimension of a is a.words*UintSize rows of b.
+func mulAdd(c, a, b block) {
+	k := a.words * bits.UintSize
+	n := b.words * bits.UintSize
+	switch {
+	case min(a.rows, k, n) >= strassenThreshold && a.rows%2 == 0 && a.words%2 == 0 && b.words%2 == 0:
+		mulAddStrassen(c, a, b)
+	case min(a.rows, k) >= m4rmThreshold:
+		mulAddM4RM(c, a, b)
+	default:
+		mulAddNaive(c, a, b)
+	}
+}
+
+// mulAddStrassen set c ^= a * b by the Strassen–Winograd recursion,
+// over GF(2) the differences are sums.
+func mulAddStrassen(c, a, b block) {
+	a11, a12, a21, a22 := a.quarters()
+	b11, b12, b21, b22 := b.quarters()
+	c11, c12, c21, c22 := c.quarters()
+	s1 := sumBlock(a21, a22)
+	s2 := sumBlock(s1, a11)
+	s3 := sumBlock(a11, a21)
+	s4 := sumBlock(a12, s2)
+	t1 := sumBlock(b12, b11)
+	t2 := sumBlock(b22, t1)
+	t3 := sumBlock(b22, b12)
+	t4 := sumBlock(t2, b21)
+	product := func(x, y block) block {
+		p := newBlock(x.rows, y.words)
+		mulAdd(p, x, y)
+		return p
+	}
+	p1 := product(a11, b11)
+	p2 := product(a12, b21)
+	p3 := product(s4, b22)
+	p4 := product(a22, t4)
+	p5 := product(s1, t1)
+	p6 := product(s2, t2)
+	p7 := product(s3, t3)
+	// u2 = p1 + p6, u3 = u2 + p7, u4 = u2 + p5
+	xorBlock(c11, p1, p2)
+	xorBlock(c12, p1, p6, p5, p3)
+	xorBlock(c21, p1, p6, p7, p4)
+	xorBlock(c22, p1, p6, p7, p5)
+}
+
+// strassenPad return the count of rows and of columns in bits, the
+// dimensions of a product are padded to, that the Strassen–Winograd
+// recursion of mulAdd splits the blocks down to strassenThreshold.
+func strassenPad(m, k, n int) (rows, cols int) {
+	d := 0
+	for min(m, k, n)>>d >= strassenThreshold {
+		d++
+	}
+	return 1 << d, bits.UintSize << d
+}
+
+// roundUp return x rounded up to a multiple of u.
+func roundUp(x, u int) int {
+	return (x + u - 1) / u * u
+}
+
+// Mul return the product a * b, row i of the product is the sum of the
+// rows j of b of the columns j set in row i of a.
+// Panic if the count of columns of a is not the count of rows of b.
+func Mul(a, b *SizedBitMatrix) *SizedBitMatrix {
+	if a.cols != b.Rows() {
+		panic(mismatch("Mul", uint(a.cols), uint(b.Rows())).Error())
+	}
+	m, k, n := a.Rows(), a.cols, b.cols
+	ru, cu := strassenPad(m, k, n)
+	ka, kw, nw := roundUp(k, cu), roundUp(k, cu)/bits.UintSize, roundUp(n, cu)/bits.UintSize
+	ba, bb := newBlock(roundUp(m, ru), kw), newBlock(ka, nw)
+	for i, row := range a.rows {
+		for j, x := range row.Bits() {
+			ba.w[i*kw+j] = uint(x)
+		}
+	}
+	// bit j of a row of a is column k-1-j, the row k-1-j of b
+	for j, row := range b.rows {
+		for l, x := range row.Bits() {
+			bb.w[(k-1-j)*nw+l] = uint(x)
+		}
+	}
+	bc := newBlock(ba.rows, nw)
+	mulAdd(bc, ba, bb)
+	c := make(BitMatrix, m)
+	for i := range c {
+		r := bc.row(i)
+		ws := make([]big.Word, len(r))
+		for l, x := range r {
+			ws[l] = big.Word(x)
+		}
+		c[i] = new(big.Int).SetBits(ws)
+	}
+	return &SizedBitMatrix{c, n}
+}
+
+// MulVec return the product m * v of m and the column vector v of
+// dimension Cols, a vector of dimension Rows. As for a GF2LinearMap
+// the coordinate i of v is the column Cols-i and row 0 is the coordinate Rows
+// of the product, m * v is the sum of the columns of the coordinates set in v.
+// Panic if v is not of dimension Cols or m has no rows.
+func (m *SizedBitMatrix) MulVec(v *GF2Vector) *GF2Vector {
+	if v.sp.dim != uint(m.cols) {
+		panic(fmt.Sprintf("MulVec(v): v.dim = %v != %v = Cols", v.sp.dim, m.cols))
+	}
+	z := NewGF2VectorSpace(uint(m.Rows())).GF2Zeros()
+	for i, row := range m.rows {
+		if rowDot(row, v) == 1 {
+			z.SetBit(uint(m.Rows()-i), 1)
+		}
+	}
+	return z
+}
@@ -0,0 +1,133 @@
+// Ralf Poeppel, 2026
+
+package gf2vs
+
+import (
+	"math/rand/v2"
+	"testing"
+)
+
+// randomMatrix return a random matrix of r rows and c columns.
+func randomMatrix(rnd *rand.Rand, r, c int) *SizedBitMatrix {
+	m := NewBitMatrix(r, c)
+	for _, row := range m.rows {
+		for j := range c {
+			row.SetBit(row, j, uint(rnd.IntN(2)))
+		}
+	}
+	return m
+}
+
+// mulBig return a * b by the xor of the big.Int rows.
+func mulBig(a, b *SizedBitMatrix) *SizedBitMatrix {
+	c := NewBitMatrix(a.Rows(), b.Cols())
+	for i, row := range a.rows {
+		for j := range a.Cols() {
+			if row.Bit(a.Cols()-1-j) == 1 {
+				c.rows[i].Xor(c.rows[i], b.rows[j])
+			}
+		}
+	}
+	return c
+}
+
+func TestMulSmall(t *testing.T) {
+	a := FromStrings([]string{"110", "011"})
+	b := FromStrings([]string{"1000", "0100", "0011"})
+	if got, want := Mul(a, b).String(), "1100\n0111\n"; got != want {
+		t.Errorf("Mul(%q, %q) = %q, want %q", a, b, got, want)
+	}
+	m := FromStrings([]string{"0110", "1001", "1111"})
+	if got := Mul(Identity(3), m); !got.Equal(m) {
+		t.Errorf("Mul(Identity(3), %q) = %q", m, got)
+	}
+	if got := Mul(m, Identity(4)); !got.Equal(m) {
+		t.Errorf("Mul(%q, Identity(4)) = %q", m, got)
+	}
+	if got := Mul(NewBitMatrix(2, 0), NewBitMatrix(0, 3)); !got.Equal(NewBitMatrix(2, 3)) {
+		t.Errorf("Mul of 2x0 and 0x3 = %q", got)
+	}
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Mul of 2x3 and 2x3 does not panic")
+		}
+	}()
+	Mul(a, a)
+}
+
+func TestMulKernels(t *testing.T) {
+	defer func(m, s int) { m4rmThreshold, strassenThreshold = m, s }(m4rmThreshold, strassenThreshold)
+	rnd := rand.New(rand.NewPCG(1, 2))
+	cases := []struct {
+		m, k, n  int
+		m4rm     int
+		strassen int
+	}{
+		{5, 7, 3, 1 << 30, 1 << 30},
+		{100, 130, 70, 1 << 30, 1 << 30},
+		{100, 130, 70, 8, 1 << 30},
+		{300, 257, 190, 8, 64},
+		{256, 256, 256, 64, 128},
+		{129, 300, 65, 8, 64},
+	}
+	for _, c := range cases {
+		m4rmThreshold, strassenThreshold = c.m4rm, c.strassen
+		a, b := randomMatrix(rnd, c.m, c.k), randomMatrix(rnd, c.k, c.n)
+		if got, want := Mul(a, b), mulBig(a, b); !got.Equal(want) {
+			t.Errorf("Mul of %vx%v and %vx%v, thresholds %v, %v differs", c.m, c.k, c.k, c.n, c.m4rm, c.strassen)
+		}
+	}
+}
+
+func TestMulVec(t *testing.T) {
+	rnd := rand.New(rand.NewPCG(1, 2))
+	m := randomMatrix(rnd, 70, 90)
+	v := randomVector(rnd, NewGF2VectorSpace(90), 32)
+	// m * v is the product with the column matrix of v
+	col := NewBitMatrix(90, 1)
+	for i := range 90 {
+		col.SetBit(89-i, 0, v.Bit(uint(i+1)))
+	}
+	p := Mul(m, col)
+	for i := range 70 {
+		if got := m.MulVec(v).Bit(uint(70 - i)); got != p.Bit(i, 0) {
+			t.Errorf("MulVec(%v) coordinate %v = %v, want %v", v, 70-i, got, p.Bit(i, 0))
+		}
+	}
+	f := NewGF2LinearMap(NewGF2VectorSpace(90), NewGF2VectorSpace(70), m.rows)
+	if got, want := m.MulVec(v).String(), f.Apply(v).String(); got != want {
+		t.Errorf("MulVec(%v) = %v, want %v", v, got, want)
+	}
+	if got := Identity(3).MulVec(NewGF2VectorSpace(3).NewGF2Vector(0b011)); got.String() != "011" {
+		t.Errorf("Identity(3).MulVec(011) = %v", got)
+	}
+}
+
+func TestMulAssociative(t *testing.T) {
+	defer func(s int) { strassenThreshold = s }(strassenThreshold)
+	strassenThreshold = 64
+	rnd := rand.New(rand.NewPCG(3, 4))
+	a, b, c := randomMatrix(rnd, 200, 150), randomMatrix(rnd, 150, 180), randomMatrix(rnd, 180, 10)
+	if !Mul(Mul(a, b), c).Equal(Mul(a, Mul(b, c))) {
+		t.Errorf("Mul is not associative")
+	}
+	// the product of the matrices of the multiplication by x and 1/x of GF(2^8)
+	f := NewGF2Field(NewGF2Poly(8, 4, 3, 1, 0))
+	sp := f.Space()
+	mul := func(x uint) *SizedBitMatrix {
+		// the matrix of v -> x*v, column c is the image of the coordinate 8-c
+		m := NewBitMatrix(8, 8)
+		for c := range 8 {
+			img := f.Mul(sp.NewGF2Vector(x), sp.GF2BaseVector(uint(8-c)))
+			for r := range 8 {
+				m.SetBit(r, c, img.Bit(uint(8-r)))
+			}
+		}
+		return m
+	}
+	x := uint(0x53)
+	y := f.Inv(sp.NewGF2Vector(x)).Val()
+	if got := Mul(mul(x), mul(y)); !got.Equal(Identity(8)) {
+		t.Errorf("Mul(%#x, %#x) = %q, want the identity", x, y, got)
+	}
+}
@@ -0,0 +1,228 @@
+// Ralf Poeppel, 2026
+//
+// This file implements permutations of the coordinates of vectors:
+// rotation, reversal and arbitrary permutations, and the gather and scatter
+// operations Compress and Expand, known as PEXT and PDEP of x86 BMI2.
+// Compress maps the coordinates selected by a mask to a dense vector of
+// a lower dimensional vector space, Expand is its inverse.
+
+package gf2vs
+
+import (
+	"fmt"
+	"math/bits"
+)
+
+// RotateLeft sets z = x with the coordinates rotated left by k within dim,
+// coordinate i of x is coordinate i+k mod dim of z, and returns z.
+// To rotate right use a negative k.
+func (z *GF2Vector) RotateLeft(x *GF2Vector, k int) *GF2Vector {
+	dim := x.sp.dim
+	s := uint(k % int(dim))
+	if k < 0 && s != 0 {
+		s = uint(k%int(dim) + int(dim))
+	}
+	if !x.sp.isWide() {
+		v := x.val
+		z.reuse(x.sp)
+		z.val = (v<<s | v>>(dim-s)) & x.sp.ones
+		return z
+	}
+	z.Set(x)
+	if s != 0 {
+		// the high s bits move to the bottom: reverse all bits, then
+		// the bits [0, s) and [s, dim) each
+		reverseBits(z.words, 0, dim)
+		reverseBits(z.words, 0, s)
+		reverseBits(z.words, s, dim)
+	}
+	return z
+}
+
+// Reverse sets z = x with the order of the coordinates reversed,
+// coordinate i of x is coordinate dim+1-i of z, and returns z.
+func (z *GF2Vector) Reverse(x *GF2Vector) *GF2Vector {
+	dim := x.sp.dim
+	if !x.sp.isWide() {
+		v := x.val
+		z.reuse(x.sp)
+		z.val = bits.Reverse(v) >> (bits.UintSize - dim)
+		return z
+	}
+	z.Set(x)
+	reverseBits(z.words, 0, dim)
+	return z
+}
+
+// getBits return the n <= UintSize bits of w from bit p on.
+func getBits(w []uint, p, n uint) uint {
+	q, o := p/bits.UintSize, p%bits.UintSize
+	v := w[q] >> o
+	if o+n > bits.UintSize {
+		v |= w[q+1] << (bits.UintSize - o)
+	}
+	if n < bits.UintSize {
+		v &= 1<<n - 1
+	}
+	return v
+}
+
+// setBits set the n <= UintSize bits of w from bit p on to v.
+func setBits(w []uint, p, n, v uint) {
+	q, o := p/bits.UintSize, p%bits.UintSize
+	mask := ^uint(0)
+	if n < bits.UintSize {
+		mask = 1<<n - 1
+	}
+	w[q] = w[q]&^(mask<<o) | (v&mask)<<o
+	if o+n > bits.UintSize {
+		r := bits.UintSize - o
+		w[q+1] = w[q+1]&^(mask>>r) | (v&mask)>>r
+	}
+}
+
+// reverseBits reverse the order of the bits [a, b) of w in place,
+// swapping reversed chunks of up to UintSize bits from both ends.
+func reverseBits(w []uint, a, b uint) {
+	for a+1 < b {
+		n := min(bits.UintSize, (b-a)/2)
+		lo, hi := getBits(w, a, n), getBits(w, b-n, n)
+		setBits(w, a, n, bits.Reverse(hi)>>(bits.UintSize-n))
+		setBits(w, b-n, n, bits.Reverse(lo)>>(bits.UintSize-n))
+		a, b = a+n, b-n
+	}
+}
+
+// Permute sets z = x with the coordinates permuted by p, coordinate i of x
+// is coordinate p[i-1] of z, and returns z.
+// Panic if p is no permutation of [1, dim], it is PermuteErr panicking with its error.
+func (z *GF2Vector) Permute(x *GF2Vector, p []uint) *GF2Vector {
+	if _, err := z.PermuteErr(x, p); err != nil {
+		panic(err.Error())
+	}
+	return z
+}
+
+// PermuteErr sets z = x with the coordinates permuted by p, coordinate i of x
+// is coordinate p[i-1] of z, and returns z.
+// Return an error wrapping ErrValueOutOfRange if p is no permutation of
+// [1, dim], z is unchanged then.
+func (z *GF2Vector) PermuteErr(x *GF2Vector, p []uint) (*GF2Vector, error) {
+	if err := checkPermutation("Permute(x, p)", x.sp.dim, p); err != nil {
+		return nil, err
+	}
+	if z == x {
+		x = x.Copy()
+	}
+	z.reuse(x.sp)
+	z.val = 0
+	clear(z.words)
+	for i := range x.Ones() {
+		z.SetBit(p[i-1], 1)
+	}
+	return z, nil
+}
+
+// checkPermutation return an error wrapping ErrValueOutOfRange if p is no
+// permutation of [1, dim].
+func checkPermutation(op string, dim uint, p []uint) error {
+	if uint(len(p)) != dim {
+		return outOfRange(op, "len(p) = %v != %v = dim", len(p), dim)
+	}
+	seen := make([]bool, dim+1)
+	for i, j := range p {
+		if j < 1 || j > dim || seen[j] {
+			return outOfRange(op, "p[%v] = %v, p is no permutation of [1, %v]", i, j, dim)
+		}
+		seen[j] = true
+	}
+	return nil
+}
+
+// pext return the bits of v selected by mask m packed to the least
+// significant bits.
+func pext(v, m uint) uint {
+	var r uint
+	for b := uint(1); m != 0; b <<= 1 {
+		if v&m&-m != 0 {
+			r |= b
+		}
+		m &= m - 1
+	}
+	return r
+}
+
+// pdep return the least significant bits of v deposited at the bits set in mask m.
+func pdep(v, m uint) uint {
+	var r uint
+	for b := uint(1); m != 0; b <<= 1 {
+		if v&b != 0 {
+			r |= m & -m
+		}
+		m &= m - 1
+	}
+	return r
+}
+
+// Compress return the coordinates of v selected by mask packed to a vector
+// of a new vector space of dimension OnesCount(mask), the coordinate of the
+// k-th one of mask is coordinate k of the result.
+// Panic if v and mask are of vector spaces of different dimension or mask
+// is the zero vector.
+func Compress(v, mask *GF2Vector) *GF2Vector {
+	sameSpace("Compress", v, mask)
+	n := OnesCount(mask)
+	if n == 0 {
+		panic("Compress(v, mask): mask is the zero vector")
+	}
+	z := NewGF2VectorSpace(uint(n)).GF2Zeros()
+	if !v.sp.isWide() {
+		z.val = pext(v.val, mask.val)
+		return z
+	}
+	k := uint(0)
+	for i := range mask.Ones() {
+		k++
+		if v.Bit(i) == 1 {
+			z.SetBit(k, 1)
+		}
+	}
+	return z
+}
+
+// Expand return the vector of the vector space of mask with coordinate k of
+// v at the coordinate of the k-th one of mask, the inverse of Compress.
+// Panic if the dimension of v is not OnesCount(mask).
+func Expand(v, mask *GF2Vector) *GF2Vector {
+	if n := uint(OnesCount(mask)); v.sp.dim != n {
+		panic(fmt.Sprintf("Expand(v, mask): v.dim = %v != %v = OnesCount(mask)", v.sp.dim, n))
+	}
+	z := mask.sp.GF2Zeros()
+	if !mask.sp.isWide() {
+		z.val = pdep(v.val, mask.val)
+		return z
+	}
+	k := uint(0)
+	for i := range mask.Ones() {
+		k++
+		if v.Bit(k) == 1 {
+			z.SetBit(i, 1)
+		}
+	}
+	return z
+}
+
+// Compress return the coordinates of v in the coordinate sub vector space sp
+// as vector of a vector space of dimension the count of coordinates of sp,
+// see Compress.
+// Panic if v is not of the vector space of sp or sp has no coordinates.
+func (sp *GF2SubVectorSpace) Compress(v *GF2Vector) *GF2Vector {
+	return Compress(v, sp.subVector())
+}
+
+// Expand return the vector of the vector space of sp with the coordinates of
+// v in the coordinate sub vector space sp, see Expand.
+// Panic if the dimension of v is not the count of coordinates of sp.
+func (sp *GF2SubVectorSpace) Expand(v *GF2Vector) *GF2Vector {
+	return Expand(v, sp.subVector())
+}
@@ -0,0 +1,218 @@
+// Ralf Poeppel, 2026
+
+package gf2vs
+
+import (
+	"math/rand/v2"
+	"testing"
+)
+
+// rotateBits return v rotated left by k coordinate by coordinate.
+func rotateBits(v *GF2Vector, k int) *GF2Vector {
+	dim := int(v.sp.dim)
+	z := v.sp.GF2Zeros()
+	for i := range v.Ones() {
+		j := ((int(i)-1+k)%dim+dim)%dim + 1
+		z.SetBit(uint(j), 1)
+	}
+	return z
+}
+
+func TestRotateLeft(t *testing.T) {
+	s := NewGF2VectorSpace(5)
+	cases := []struct {
+		x    *GF2Vector
+		k    int
+		want string
+	}{
+		{s.NewGF2Vector(0b00011), 0, "00011"},
+		{s.NewGF2Vector(0b00011), 1, "00110"},
+		{s.NewGF2Vector(0b10001), 1, "00011"},
+		{s.NewGF2Vector(0b10001), -1, "11000"},
+		{s.NewGF2Vector(0b10001), 5, "10001"},
+		{s.NewGF2Vector(0b10001), 12, "00110"},
+		{s.NewGF2Vector(0b10001), -12, "01100"},
+	}
+	for _, c := range cases {
+		if got := new(GF2Vector).RotateLeft(c.x, c.k); got.String() != c.want {
+			t.Errorf("RotateLeft(%v, %v) = %v, want %v", c.x, c.k, got, c.want)
+		}
+	}
+
+	r := rand.New(rand.NewPCG(7, 8))
+	for _, dim := range []uint{1, 63, 64, 65, 127, 130, 256} {
+		sp := NewGF2VectorSpace(dim)
+		for _, k := range []int{0, 1, -1, 3, 64, -65, 129, 200} {
+			x := randomVector(r, sp, 2)
+			want := rotateBits(x, k).String()
+			if got := new(GF2Vector).RotateLeft(x, k); got.String() != want {
+				t.Errorf("RotateLeft(%v, %v) = %v, want %v", x, k, got, want)
+			}
+			if got := x.RotateLeft(x, k); got.String() != want {
+				t.Errorf("x.RotateLeft(x, %v) = %v, want %v", k, got, want)
+			}
+		}
+	}
+	// the words are shifted in place
+	x := randomVector(r, NewGF2VectorSpace(1000), 2)
+	if n := testing.AllocsPerRun(10, func() { x.RotateLeft(x, 77).Reverse(x) }); n != 0 {
+		t.Errorf("RotateLeft and Reverse of a wide vector allocate %v times", n)
+	}
+}
+
+func TestReverse(t *testing.T) {
+	r := rand.New(rand.NewPCG(9, 10))
+	for _, dim := range []uint{1, 5, 64, 65, 127, 130, 256} {
+		sp := NewGF2VectorSpace(dim)
+		for range 5 {
+			x := randomVector(r, sp, 2)
+			s := []byte(x.String())
+			for i, j := 0, len(s)-1; i < j; i, j = i+1, j-1 {
+				s[i], s[j] = s[j], s[i]
+			}
+			want := string(s)
+			if got := new(GF2Vector).Reverse(x); got.String() != want {
+				t.Errorf("Reverse(%v) = %v, want %v", x, got, want)
+			}
+			if got := x.Reverse(x); got.String() != want {
+				t.Errorf("x.Reverse(x) = %v, want %v", got, want)
+			}
+		}
+	}
+}
+
+func TestPermute(t *testing.T) {
+	s := NewGF2VectorSpace(4)
+	x := s.NewGF2Vector(0b0011)
+	cases := []struct {
+		p    []uint
+		want string
+	}{
+		{[]uint{1, 2, 3, 4}, "0011"},
+		{[]uint{4, 3, 2, 1}, "1100"},
+		{[]uint{2, 3, 4, 1}, "0110"},
+		{[]uint{3, 1, 2, 4}, "0101"},
+	}
+	for _, c := range cases {
+		if got := new(GF2Vector).Permute(x, c.p); got.String() != c.want {
+			t.Errorf("Permute(%v, %v) = %v, want %v", x, c.p, got, c.want)
+		}
+	}
+
+	// rotation and reversal are permutations
+	r := rand.New(rand.NewPCG(11, 12))
+	for _, dim := range []uint{7, 70} {
+		sp := NewGF2VectorSpace(dim)
+		rot, rev := make([]uint, dim), make([]uint, dim)
+		for i := range dim {
+			rot[i] = (i+3)%dim + 1
+			rev[i] = dim - i
+		}
+		x := randomVector(r, sp, 2)
+		if got, want := new(GF2Vector).Permute(x, rot), new(GF2Vector).RotateLeft(x, 3); got.String() != want.String() {
+			t.Errorf("Permute(%v, rot) = %v, want %v", x, got, want)
+		}
+		if got, want := x.Copy().Permute(x, rev), new(GF2Vector).Reverse(x); got.String() != want.String() {
+			t.Errorf("Permute(%v, rev) = %v, want %v", x, got, want)
+		}
+	}
+
+	errs := []struct {
+		p    []uint
+		want string
+	}{
+		{[]uint{1, 2, 3}, "Permute(x, p): len(p) = 3 != 4 = dim"},
+		{[]uint{1, 2, 3, 5}, "Permute(x, p): p[3] = 5, p is no permutation of [1, 4]"},
+		{[]uint{0, 1, 2, 3}, "Permute(x, p): p[0] = 0, p is no permutation of [1, 4]"},
+		{[]uint{1, 2, 2, 3}, "Permute(x, p): p[2] = 2, p is no permutation of [1, 4]"},
+	}
+	for _, c := range errs {
+		z := s.NewGF2Vector(5)
+		if _, err := z.PermuteErr(x, c.p); err == nil || err.Error() != c.want || z.String() != "0101" {
+			t.Errorf("PermuteErr(%v, %v) = %v, %v, want %v", x, c.p, z, err, c.want)
+		}
+	}
+	defer func() {
+		if r := recover(); r != errs[0].want {
+			t.Errorf("Permute panic %v, want %v", r, errs[0].want)
+		}
+	}()
+	new(GF2Vector).Permute(x, errs[0].p)
+}
+
+func TestCompressExpand(t *testing.T) {
+	s := NewGF2VectorSpace(8)
+	cases := []struct {
+		v, mask uint
+		want    string
+	}{
+		{0b10110110, 0b11110000, "1011"},
+		{0b10110110, 0b00001111, "0110"},
+		{0b10110110, 0b10101010, "1101"},
+		{0b10110110, 0b11111111, "10110110"},
+		{0b10110110, 0b00000001, "0"},
+	}
+	for _, c := range cases {
+		v, mask := s.NewGF2Vector(c.v), s.NewGF2Vector(c.mask)
+		got := Compress(v, mask)
+		if got.String() != c.want {
+			t.Errorf("Compress(%v, %v) = %v, want %v", v, mask, got, c.want)
+		}
+		if e, want := Expand(got, mask), And(v, mask); e.String() != want.String() {
+			t.Errorf("Expand(%v, %v) = %v, want %v", got, mask, e, want)
+		}
+	}
+
+	r := rand.New(rand.NewPCG(13, 14))
+	for _, dim := range []uint{64, 65, 200} {
+		sp := NewGF2VectorSpace(dim)
+		for range 5 {
+			v, mask := randomVector(r, sp, 2), randomVector(r, sp, 2)
+			c := Compress(v, mask)
+			k := uint(0)
+			for i := range mask.Ones() {
+				k++
+				if c.Bit(k) != v.Bit(i) {
+					t.Fatalf("Compress(%v, %v) = %v, coordinate %v", v, mask, c, k)
+				}
+			}
+			if e, want := Expand(c, mask), And(v, mask); e.String() != want.String() {
+				t.Errorf("Expand(%v, %v) = %v, want %v", c, mask, e, want)
+			}
+		}
+	}
+
+	s6 := NewGF2VectorSpace(6)
+	sub := new(GF2SubVectorSpace)
+	sub.setSubVector(s6.NewGF2Vector(0b101100))
+	v := s6.NewGF2Vector(0b100101)
+	if got := sub.Compress(v); got.String() != "101" {
+		t.Errorf("%v.Compress(%v) = %v, want 101", sub, v, got)
+	}
+	if got := sub.Expand(NewGF2VectorSpace(3).NewGF2Vector(0b011)); got.String() != "001100" {
+		t.Errorf("%v.Expand(011) = %v, want 001100", sub, got)
+	}
+}
+
+func TestCompressExpandPanic(t *testing.T) {
+	s := NewGF2VectorSpace(4)
+	cases := []struct {
+		f    func()
+		want string
+	}{
+		{func() { Compress(s.GF2Ones(), s.GF2Zeros()) }, "Compress(v, mask): mask is the zero vector"},
+		{func() { Compress(s.GF2Ones(), NewGF2VectorSpace(3).GF2Ones()) },
+			"Compress: incompatible vector spaces: z.dim = 4 != 3 = y.dim"},
+		{func() { Expand(s.GF2Ones(), s.NewGF2Vector(7)) }, "Expand(v, mask): v.dim = 4 != 3 = OnesCount(mask)"},
+	}
+	for _, c := range cases {
+		func() {
+			defer func() {
+				if r := recover(); r != c.want {
+					t.Errorf("panic %v, want %v", r, c.want)
+				}
+			}()
+			c.f()
+		}()
+	}
+}
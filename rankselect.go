@@ -0,0 +1,155 @@
+// Ralf Poeppel, 2026
+//
+// This file implements rank and select queries on vectors.
+// Rank1(i) is the count of ones before the coordinate with index i,
+// Select1(k) is the index of the k-th one. The indices are 1-based as
+// the indices of GF2BaseVector.
+
+package gf2vs
+
+import (
+	"fmt"
+	"math/bits"
+	"sort"
+)
+
+// checkRank panic if i is out of range [1, dim+1] for Rank1.
+func checkRank(i, dim uint) {
+	if i == 0 || dim+1 < i {
+		panic(fmt.Sprintf("Rank1(i): i = %v out of range [1, %v]", i, dim+1))
+	}
+}
+
+// selectWord return the 0-based position of the k-th one, k >= 1, in w.
+// w must have at least k ones.
+func selectWord(w uint, k int) uint {
+	// skip whole bytes
+	var pos uint
+	for {
+		c := bits.OnesCount8(uint8(w))
+		if c >= k {
+			break
+		}
+		k -= c
+		w >>= 8
+		pos += 8
+	}
+	for ; k > 1; k-- {
+		w &= w - 1
+	}
+	return pos + uint(bits.TrailingZeros(w))
+}
+
+// Rank1 return the count of ones of v before the coordinate with index i,
+// that is of the coordinates 1, ..., i-1. Rank1(dim+1) is OnesCount(v).
+// Panic if i is out of range [1, dim+1].
+func (v *GF2Vector) Rank1(i uint) int {
+	checkRank(i, v.sp.dim)
+	i--
+	ws := wordsOf(v)
+	n := i / bits.UintSize
+	r := 0
+	for _, w := range ws[:n] {
+		r += bits.OnesCount(w)
+	}
+	if b := i % bits.UintSize; b > 0 {
+		r += bits.OnesCount(ws[n] & (uint(1)<<b - 1))
+	}
+	return r
+}
+
+// Select1 return the index of the k-th one of v, k >= 1.
+// Index is zero and ok is false if v has less than k ones.
+func (v *GF2Vector) Select1(k int) (index uint, ok bool) {
+	if k < 1 {
+		return
+	}
+	for j, w := range wordsOf(v) {
+		c := bits.OnesCount(w)
+		if c >= k {
+			return uint(j)*bits.UintSize + selectWord(w, k) + 1, true
+		}
+		k -= c
+	}
+	return
+}
+
+// wordsPerBlock the count of words of a block of RankSelect.
+const wordsPerBlock = 8
+
+// RankSelect is an immutable index of a vector answering Rank1 in O(1)
+// and Select1 in O(log n). The count of ones before each block of
+// wordsPerBlock words is precomputed.
+type RankSelect struct {
+	dim    uint   // dimension of the vector space of the vector
+	words  []uint // copy of the value of the vector
+	blocks []int  // count of ones before each block, and total count at the end
+}
+
+// NewRankSelect create the rank and select index of v.
+// The value of v is copied, later changes of v do not change the index.
+func NewRankSelect(v *GF2Vector) *RankSelect {
+	ws := v.Words()
+	nb := (len(ws) + wordsPerBlock - 1) / wordsPerBlock
+	blocks := make([]int, nb+1)
+	c := 0
+	for b := range nb {
+		blocks[b] = c
+		for _, w := range ws[b*wordsPerBlock : min((b+1)*wordsPerBlock, len(ws))] {
+			c += bits.OnesCount(w)
+		}
+	}
+	blocks[nb] = c
+	return &RankSelect{v.sp.dim, ws, blocks}
+}
+
+func (rs *RankSelect) String() string {
+	return fmt.Sprintf("RankSelect{%v: %v}", rs.dim, rs.OnesCount())
+}
+
+// Dim return the dimension of the vector space of the indexed vector.
+func (rs *RankSelect) Dim() uint {
+	return rs.dim
+}
+
+// OnesCount return the count of ones of the indexed vector.
+func (rs *RankSelect) OnesCount() int {
+	return rs.blocks[len(rs.blocks)-1]
+}
+
+// Rank1 return the count of ones before the coordinate with index i,
+// that is of the coordinates 1, ..., i-1.
+// Panic if i is out of range [1, dim+1].
+func (rs *RankSelect) Rank1(i uint) int {
+	checkRank(i, rs.dim)
+	i--
+	n := int(i / bits.UintSize)
+	b := n / wordsPerBlock
+	r := rs.blocks[b]
+	for _, w := range rs.words[b*wordsPerBlock : n] {
+		r += bits.OnesCount(w)
+	}
+	if m := i % bits.UintSize; m > 0 {
+		r += bits.OnesCount(rs.words[n] & (uint(1)<<m - 1))
+	}
+	return r
+}
+
+// Select1 return the index of the k-th one, k >= 1.
+// Index is zero and ok is false if there are less than k ones.
+func (rs *RankSelect) Select1(k int) (index uint, ok bool) {
+	if k < 1 || k > rs.OnesCount() {
+		return
+	}
+	// last block with less than k ones before it
+	b := sort.SearchInts(rs.blocks, k) - 1
+	k -= rs.blocks[b]
+	for j := b * wordsPerBlock; ; j++ {
+		w := rs.words[j]
+		c := bits.OnesCount(w)
+		if c >= k {
+			return uint(j)*bits.UintSize + selectWord(w, k) + 1, true
+		}
+		k -= c
+	}
+}
@@ -0,0 +1,95 @@
+// Ralf Poeppel, 2026
+
+package gf2vs
+
+import (
+	"math/rand/v2"
+	"testing"
+)
+
+// randomVector return a random vector of sp with about density ones per word.
+func randomVector(r *rand.Rand, sp *GF2VectorSpace, density int) *GF2Vector {
+	v := sp.GF2Zeros()
+	for i := uint(1); i <= sp.dim; i++ {
+		if r.IntN(64) < density {
+			v.SetBit(i, 1)
+		}
+	}
+	return v
+}
+
+func TestRankSelect(t *testing.T) {
+	r := rand.New(rand.NewPCG(1, 2))
+	cases := []struct {
+		dim     uint
+		density int
+	}{
+		{1, 32},
+		{7, 32},
+		{64, 32},
+		{65, 1},
+		{512, 32},
+		{600, 0},
+		{1000, 64},
+		{1000, 32},
+		{10000, 1},
+		{10000, 20},
+	}
+	for _, c := range cases {
+		v := randomVector(r, NewGF2VectorSpace(c.dim), c.density)
+		rs := NewRankSelect(v)
+		if rs.Dim() != c.dim || rs.OnesCount() != OnesCount(v) {
+			t.Errorf("NewRankSelect(%v) = %v, want dim %v, ones %v",
+				c.dim, rs, c.dim, OnesCount(v))
+		}
+		// naive rank and select
+		rank := 0
+		k := 0
+		for i := uint(1); i <= c.dim+1; i++ {
+			if got := v.Rank1(i); got != rank {
+				t.Fatalf("%v: v.Rank1(%v) = %v, want %v", c.dim, i, got, rank)
+			}
+			if got := rs.Rank1(i); got != rank {
+				t.Fatalf("%v: rs.Rank1(%v) = %v, want %v", c.dim, i, got, rank)
+			}
+			if i <= c.dim && v.Bit(i) == 1 {
+				rank++
+				k++
+				if got, ok := v.Select1(k); got != i || !ok {
+					t.Fatalf("%v: v.Select1(%v) = %v, %v, want %v, true", c.dim, k, got, ok, i)
+				}
+				if got, ok := rs.Select1(k); got != i || !ok {
+					t.Fatalf("%v: rs.Select1(%v) = %v, %v, want %v, true", c.dim, k, got, ok, i)
+				}
+			}
+		}
+		for _, k := range []int{0, -1, rank + 1} {
+			if got, ok := v.Select1(k); got != 0 || ok {
+				t.Errorf("%v: v.Select1(%v) = %v, %v, want 0, false", c.dim, k, got, ok)
+			}
+			if got, ok := rs.Select1(k); got != 0 || ok {
+				t.Errorf("%v: rs.Select1(%v) = %v, %v, want 0, false", c.dim, k, got, ok)
+			}
+		}
+	}
+
+	// the index is not changed by changes of the vector
+	v := NewGF2VectorSpace(100).GF2Ones()
+	rs := NewRankSelect(v)
+	v.SetBit(1, 0)
+	if got := rs.Rank1(101); got != 100 {
+		t.Errorf("rs.Rank1(101) = %v after change of vector, want 100", got)
+	}
+
+	want := "Rank1(i): i = 102 out of range [1, 101]"
+	for _, f := range []func(){func() { v.Rank1(102) }, func() { rs.Rank1(102) }} {
+		func() {
+			defer func() {
+				if r := recover(); r != want {
+					t.Errorf("Rank1(102) == Panic(%v), want Panic(%v)", r, want)
+				}
+			}()
+			f()
+		}()
+	}
+}
@@ -0,0 +1,230 @@
+// Ralf Poeppel, 2026
+//
+// This file implements sub vector spaces spanned by a set of vectors.
+// The span is held as reduced basis in row reduced echolon form:
+// the basis vectors are ordered by their leading coordinate descending,
+// and the leading coordinate of each basis vector is zero in all other
+// basis vectors. This form is unique for each sub vector space.
+
+package gf2vs
+
+import (
+	"fmt"
+	"math/bits"
+	"slices"
+)
+
+// GF2Span represents a sub vector space spanned by a set of vectors.
+type GF2Span struct {
+	sp    *GF2VectorSpace // the space containing the sub vector space
+	basis []*GF2Vector    // reduced basis, leading coordinate descending
+}
+
+// leading return the index of the most significant coordinate set in v,
+// it is zero if v is the zero vector.
+func leading(v *GF2Vector) uint {
+	ws := wordsOf(v)
+	for k := len(ws) - 1; k >= 0; k-- {
+		if ws[k] != 0 {
+			return uint(k)*bits.UintSize + uint(bits.Len(ws[k]))
+		}
+	}
+	return 0
+}
+
+// NewGF2Span create the sub vector space {0} of s.
+func (s *GF2VectorSpace) NewGF2Span() *GF2Span {
+	return &GF2Span{sp: s}
+}
+
+// SpanOf return the span of the vectors s, computed by Gaussian elimination.
+// Panic if s is empty or x_i and x_j are of vector spaces of different
+// dimension, it is SpanOfErr panicking with its error.
+func SpanOf(s []*GF2Vector) *GF2Span {
+	span, err := SpanOfErr(s)
+	if err != nil {
+		panic(err.Error())
+	}
+	return span
+}
+
+// SpanOfErr return the span of the vectors s, computed by Gaussian elimination.
+// Return an error wrapping ErrDimensionMismatch, if x_i and x_j are of vector
+// spaces of different dimension, or wrapping ErrValueOutOfRange if s is empty.
+func SpanOfErr(s []*GF2Vector) (*GF2Span, error) {
+	if err := checkSpaces("SpanOf", s); err != nil {
+		return nil, err
+	}
+	span := s[0].sp.NewGF2Span()
+	for _, v := range s {
+		span.add(v)
+	}
+	return span, nil
+}
+
+// Span return the coordinate sub vector space sp as span of its base vectors.
+func (sp *GF2SubVectorSpace) Span() *GF2Span {
+	s := &sp.GF2VectorSpace
+	sub := s.NewGF2Vector(sp.subOnes)
+	if s.isWide() {
+		sub = s.NewGF2VectorWords(sp.subWords)
+	}
+	span := s.NewGF2Span()
+	for i := range sub.Ones() {
+		span.basis = append(span.basis, s.GF2BaseVector(i))
+	}
+	slices.Reverse(span.basis)
+	return span
+}
+
+// reduce set z to the remainder of v after elimination of the leading
+// coordinates of the basis vectors and return z.
+// z is the zero vector if and only if v is contained in the span.
+func (span *GF2Span) reduce(z, v *GF2Vector) *GF2Vector {
+	z.Set(v)
+	for _, b := range span.basis {
+		if z.Bit(leading(b)) == 1 {
+			z.Xor(z, b)
+		}
+	}
+	return z
+}
+
+// add extend the span by v, return false if v is contained in the span.
+func (span *GF2Span) add(v *GF2Vector) bool {
+	w := span.reduce(new(GF2Vector), v)
+	p := leading(w)
+	if p == 0 {
+		return false
+	}
+	// eliminate the new leading coordinate from the basis
+	for _, b := range span.basis {
+		if b.Bit(p) == 1 {
+			b.Xor(b, w)
+		}
+	}
+	i, _ := slices.BinarySearchFunc(span.basis, p, func(b *GF2Vector, p uint) int {
+		return int(p) - int(leading(b))
+	})
+	span.basis = slices.Insert(span.basis, i, w)
+	return true
+}
+
+func (span *GF2Span) String() string {
+	return fmt.Sprintf("GF(2)span{%v: %v}", span.sp.dim, span.basis)
+}
+
+// Space return the vector space containing span.
+func (span *GF2Span) Space() *GF2VectorSpace {
+	return span.sp
+}
+
+// Dim return the dimension of span, the count of vectors of its basis.
+func (span *GF2Span) Dim() uint {
+	return uint(len(span.basis))
+}
+
+// Basis return a copy of the reduced basis of span,
+// ordered by the leading coordinate descending.
+func (span *GF2Span) Basis() []*GF2Vector {
+	b := make([]*GF2Vector, len(span.basis))
+	for i, v := range span.basis {
+		b[i] = v.Copy()
+	}
+	return b
+}
+
+// Contains return true if v is an element of span.
+// Panic if v is of a vector space of other dimension.
+func (span *GF2Span) Contains(v *GF2Vector) bool {
+	if span.sp.dim != v.sp.dim {
+		panic(mismatch("Contains", span.sp.dim, v.sp.dim).Error())
+	}
+	return span.reduce(new(GF2Vector), v).IsZeros()
+}
+
+// Equal return true if span and other are the same sub vector space.
+func (span *GF2Span) Equal(other *GF2Span) bool {
+	return span.sp.dim == other.sp.dim &&
+		slices.EqualFunc(span.basis, other.basis, func(a, b *GF2Vector) bool {
+			return slices.Equal(wordsOf(a), wordsOf(b))
+		})
+}
+
+// OrthogonalComplement return the orthogonal complement of span, the dual
+// space of all vectors v with Dot(v, b) = 0 for all b of span.
+// For each coordinate f, which is no leading coordinate of the reduced basis,
+// the complement contains the vector with coordinate f and the leading
+// coordinates of the basis vectors with coordinate f set.
+func (span *GF2Span) OrthogonalComplement() *GF2Span {
+	s := span.sp
+	pivot := s.GF2Zeros()
+	for _, b := range span.basis {
+		pivot.SetBit(leading(b), 1)
+	}
+	c := s.NewGF2Span()
+	for f := range pivot.Unset() {
+		u := s.GF2BaseVector(f)
+		for _, b := range span.basis {
+			if b.Bit(f) == 1 {
+				u.SetBit(leading(b), 1)
+			}
+		}
+		c.add(u)
+	}
+	return c
+}
+
+// IsSelfOrthogonal return true if span is contained in its orthogonal
+// complement, Dot(a, b) = 0 for all a and b of span.
+func (span *GF2Span) IsSelfOrthogonal() bool {
+	for i, a := range span.basis {
+		for _, b := range span.basis[i:] {
+			if Dot(a, b) != 0 {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// IsSelfDual return true if span is equal to its orthogonal complement.
+func (span *GF2Span) IsSelfDual() bool {
+	return 2*span.Dim() == span.sp.dim && span.IsSelfOrthogonal()
+}
+
+// OrthogonalComplement return the orthogonal complement of the coordinate
+// sub vector space sp, the sub vector space of the other coordinates.
+func (sp *GF2SubVectorSpace) OrthogonalComplement() *GF2SubVectorSpace {
+	c := GF2SubVectorSpace{sp.GF2VectorSpace, sp.ones ^ sp.subOnes, nil}
+	if sp.isWide() {
+		sub := sp.GF2VectorSpace.NewGF2VectorWords(sp.subWords)
+		c.subOnes = 0
+		c.subWords = Not(sub).words
+	}
+	return &c
+}
+
+// Sum return the sum of span and other, the span of the union of both.
+// Panic if span and other are of vector spaces of different dimension.
+func (span *GF2Span) Sum(other *GF2Span) *GF2Span {
+	if span.sp.dim != other.sp.dim {
+		panic(mismatch("Sum", span.sp.dim, other.sp.dim).Error())
+	}
+	s := span.sp.NewGF2Span()
+	s.basis = span.Basis()
+	for _, b := range other.basis {
+		s.add(b)
+	}
+	return s
+}
+
+// Intersect return the intersection of span and other,
+// the orthogonal complement of the sum of the orthogonal complements.
+// Panic if span and other are of vector spaces of different dimension.
+func (span *GF2Span) Intersect(other *GF2Span) *GF2Span {
+	if span.sp.dim != other.sp.dim {
+		panic(mismatch("Intersect", span.sp.dim, other.sp.dim).Error())
+	}
+	return span.OrthogonalComplement().Sum(other.OrthogonalComplement()).OrthogonalComplement()
+}
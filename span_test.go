@@ -0,0 +1,227 @@
+// Ralf Poeppel, 2026
+
+package gf2vs
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"testing"
+)
+
+// vectors return the vectors of space sp with the given values.
+func vectors(sp *GF2VectorSpace, val ...uint) []*GF2Vector {
+	s := make([]*GF2Vector, len(val))
+	for i, v := range val {
+		s[i] = sp.NewGF2Vector(v)
+	}
+	return s
+}
+
+func TestSpanOf(t *testing.T) {
+	cases := []struct {
+		dim   uint
+		s     []uint
+		want  string
+		in    []uint
+		notIn []uint
+	}{
+		{1, []uint{0}, "GF(2)span{1: []}", []uint{0}, []uint{1}},
+		{2, []uint{1}, "GF(2)span{2: [01]}", []uint{0, 1}, []uint{2, 3}},
+		{3, []uint{0b011, 0b110}, "GF(2)span{3: [101 011]}",
+			[]uint{0, 3, 5, 6}, []uint{1, 2, 4, 7}},
+		{3, []uint{0b011, 0b110, 0b101}, "GF(2)span{3: [101 011]}",
+			[]uint{0, 3, 5, 6}, []uint{1, 2, 4, 7}},
+		{3, []uint{0b111, 0b110, 0b101}, "GF(2)span{3: [100 010 001]}",
+			[]uint{0, 1, 2, 3, 4, 5, 6, 7}, nil},
+		{4, []uint{0b1100, 0b0110, 0b0011, 0b1001}, "GF(2)span{4: [1001 0101 0011]}",
+			[]uint{0b1111, 0b1010}, []uint{0b0001, 0b1110}},
+	}
+	for _, c := range cases {
+		sp := NewGF2VectorSpace(c.dim)
+		s := vectors(sp, c.s...)
+		span := SpanOf(s)
+		if got := span.String(); got != c.want {
+			t.Errorf("SpanOf(%v) = %v, want %v", s, got, c.want)
+		}
+		if got := span.Dim(); got != uint(len(span.Basis())) {
+			t.Errorf("SpanOf(%v).Dim() = %v, want %v", s, got, len(span.Basis()))
+		}
+		for _, v := range vectors(sp, c.in...) {
+			if !span.Contains(v) {
+				t.Errorf("SpanOf(%v).Contains(%v) = false, want true", s, v)
+			}
+		}
+		for _, v := range vectors(sp, c.notIn...) {
+			if span.Contains(v) {
+				t.Errorf("SpanOf(%v).Contains(%v) = true, want false", s, v)
+			}
+		}
+		// the basis spans the same space
+		if b := span.Basis(); len(b) > 0 && !SpanOf(b).Equal(span) {
+			t.Errorf("SpanOf(%v.Basis()) = %v, want %v", s, SpanOf(b), span)
+		}
+	}
+
+	sp1 := NewGF2VectorSpace(1)
+	sp2 := NewGF2VectorSpace(2)
+	if _, err := SpanOfErr(nil); err == nil {
+		t.Errorf("SpanOfErr(nil) = nil, want error")
+	}
+	if _, err := SpanOfErr([]*GF2Vector{sp1.GF2Ones(), sp2.GF2Ones()}); err == nil {
+		t.Errorf("SpanOfErr(1, 11) = nil, want error")
+	}
+}
+
+func TestWideSpanOf(t *testing.T) {
+	r := rand.New(rand.NewPCG(3, 4))
+	sp := NewGF2VectorSpace(300)
+	// 20 random vectors are independent with high probability,
+	// their sums are in the span.
+	s := make([]*GF2Vector, 20)
+	for i := range s {
+		s[i] = randomVector(r, sp, 32)
+	}
+	span := SpanOf(s)
+	if span.Dim() != 20 {
+		t.Fatalf("SpanOf(20 random vectors).Dim() = %v, want 20", span.Dim())
+	}
+	sum := Xor(s[1], s[5], s[19])
+	if !span.Contains(sum) {
+		t.Errorf("span.Contains(sum) = false, want true")
+	}
+	sum.FlipBit(r.UintN(300) + 1)
+	if span.Contains(sum) {
+		t.Errorf("span.Contains(sum + e_i) = true, want false")
+	}
+	s = append(s, Xor(s[2], s[3]))
+	if got := SpanOf(s); !got.Equal(span) {
+		t.Errorf("SpanOf(s, s2 + s3) = %v, want %v", got, span)
+	}
+}
+
+func TestSubVectorSpaceSpan(t *testing.T) {
+	cases := []struct {
+		n, b uint
+		want string
+	}{
+		{3, 0, "GF(2)span{3: []}"},
+		{4, 4, "GF(2)span{4: [0100]}"},
+		{4, 3, "GF(2)span{4: [0010 0001]}"},
+	}
+	for _, c := range cases {
+		got := fmt.Sprint(NewGF2SubVectorSpace(c.n, c.b).Span())
+		if got != c.want {
+			t.Errorf("NewGF2SubVectorSpace(%v, %v).Span() = %v, want %v", c.n, c.b, got, c.want)
+		}
+	}
+	ssp := NewGF2SubVectorSpace(100, 7)
+	if got := ssp.Span().Dim(); got != 3 {
+		t.Errorf("NewGF2SubVectorSpace(100, 7).Span().Dim() = %v, want 3", got)
+	}
+}
+
+func TestOrthogonalComplement(t *testing.T) {
+	cases := []struct {
+		dim      uint
+		s        []uint
+		want     string
+		selfOrth bool
+		selfDual bool
+	}{
+		{1, []uint{0}, "GF(2)span{1: [1]}", true, false},
+		{1, []uint{1}, "GF(2)span{1: []}", false, false},
+		{2, []uint{0b11}, "GF(2)span{2: [11]}", true, true},
+		{3, []uint{0b111}, "GF(2)span{3: [101 011]}", false, false},
+		{3, []uint{0b011, 0b110}, "GF(2)span{3: [111]}", false, false},
+		{4, []uint{0b1111}, "GF(2)span{4: [1001 0101 0011]}", true, false},
+		{4, []uint{0b1100, 0b0011}, "GF(2)span{4: [1100 0011]}", true, true},
+		// extended Hamming code [8, 4, 4]
+		{8, []uint{0b11110000, 0b11001100, 0b10101010, 0b11111111},
+			"GF(2)span{8: [10010110 01010101 00110011 00001111]}", true, true},
+		// Hamming code [7, 4, 3], its dual is the simplex code
+		{7, []uint{0b1110000, 0b1001100, 0b0101010, 0b1101001},
+			"GF(2)span{7: [1010101 0110011 0001111]}", false, false},
+	}
+	for _, c := range cases {
+		sp := NewGF2VectorSpace(c.dim)
+		s := vectors(sp, c.s...)
+		span := SpanOf(s)
+		dual := span.OrthogonalComplement()
+		if got := dual.String(); got != c.want {
+			t.Errorf("SpanOf(%v).OrthogonalComplement() = %v, want %v", s, got, c.want)
+		}
+		if span.Dim()+dual.Dim() != c.dim {
+			t.Errorf("dim %v + dim %v of complement != %v", span.Dim(), dual.Dim(), c.dim)
+		}
+		for _, a := range span.Basis() {
+			for _, b := range dual.Basis() {
+				if Dot(a, b) != 0 {
+					t.Errorf("Dot(%v, %v) = 1 of span and complement", a, b)
+				}
+			}
+		}
+		if got := dual.OrthogonalComplement(); !got.Equal(span) {
+			t.Errorf("SpanOf(%v).OrthogonalComplement().OrthogonalComplement() = %v, want %v",
+				s, got, span)
+		}
+		if got := span.IsSelfOrthogonal(); got != c.selfOrth {
+			t.Errorf("SpanOf(%v).IsSelfOrthogonal() = %v, want %v", s, got, c.selfOrth)
+		}
+		if got := span.IsSelfDual(); got != c.selfDual {
+			t.Errorf("SpanOf(%v).IsSelfDual() = %v, want %v", s, got, c.selfDual)
+		}
+		if got := span.IsSelfDual(); got != span.Equal(dual) {
+			t.Errorf("SpanOf(%v).IsSelfDual() = %v, but complement is %v", s, got, dual)
+		}
+	}
+}
+
+func TestWideOrthogonalComplement(t *testing.T) {
+	r := rand.New(rand.NewPCG(5, 6))
+	sp := NewGF2VectorSpace(150)
+	s := make([]*GF2Vector, 40)
+	for i := range s {
+		s[i] = randomVector(r, sp, 16)
+	}
+	span := SpanOf(s)
+	dual := span.OrthogonalComplement()
+	if span.Dim()+dual.Dim() != 150 {
+		t.Errorf("dim %v + dim %v of complement != 150", span.Dim(), dual.Dim())
+	}
+	for _, a := range s {
+		for _, b := range dual.Basis() {
+			if Dot(a, b) != 0 {
+				t.Fatalf("Dot(%v, %v) = 1 of span and complement", a, b)
+			}
+		}
+	}
+	if !dual.OrthogonalComplement().Equal(span) {
+		t.Errorf("complement of complement is not the span")
+	}
+}
+
+func TestSubVectorSpaceOrthogonalComplement(t *testing.T) {
+	cases := []struct {
+		n, b uint
+		want string
+	}{
+		{3, 0, "GF(2)ssp{3: 7, 7}"},
+		{3, 3, "GF(2)ssp{3: 7, 4}"},
+		{4, 4, "GF(2)ssp{4: 15, 11}"},
+	}
+	for _, c := range cases {
+		ssp := NewGF2SubVectorSpace(c.n, c.b)
+		got := ssp.OrthogonalComplement()
+		if got.String() != c.want {
+			t.Errorf("%v.OrthogonalComplement() = %v, want %v", ssp, got, c.want)
+		}
+		if !got.Span().Equal(ssp.Span().OrthogonalComplement()) {
+			t.Errorf("%v.OrthogonalComplement() = %v, want %v",
+				ssp, got.Span(), ssp.Span().OrthogonalComplement())
+		}
+	}
+	ssp := NewGF2SubVectorSpace(100, 7)
+	if got := ssp.OrthogonalComplement().Span().Dim(); got != 97 {
+		t.Errorf("%v.OrthogonalComplement() has dim %v, want 97", ssp, got)
+	}
+}